@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fafeitsch/Horologium/horologium"
+	"github.com/urfave/cli/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func benchmarkCommand() *cli.Command {
+	var referenceFile string
+	var dateString string
+	referenceFlag := cli.StringFlag{Name: "reference", Aliases: []string{"r"}, Required: true,
+		Usage: "The YAML or CSV file with the reference consumptions.", Destination: &referenceFile}
+	dateFlag := cli.StringFlag{Name: "date", Usage: "The end of the twelve months that are classified (defaults to today).", Destination: &dateString}
+	return &cli.Command{
+		Name:      "benchmark",
+		Usage:     "Classifies the consumption of the last twelve months into the efficiency bands of a reference table.",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Flags:     []cli.Flag{&referenceFlag, &dateFlag},
+		Action: func(context *cli.Context) error {
+			now := time.Now()
+			date := horologium.CreateDate(now.Year(), int(now.Month()), now.Day())
+			if dateString != "" {
+				parsed, err := time.Parse(horologium.DateFormat, dateString)
+				if err != nil {
+					return fmt.Errorf("could not parse date: %v", err)
+				}
+				date = parsed
+			}
+			benchmark, err := loadBenchmark(referenceFile)
+			if err != nil {
+				return err
+			}
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			for index, series := range portfolio {
+				if index > 0 {
+					fmt.Println()
+				}
+				result, err := series.Benchmark(*benchmark, date)
+				if err != nil {
+					fmt.Printf("%s: %v\n", series.Name, err)
+					continue
+				}
+				fmt.Printf("%s\n\n", series.Name)
+				result.Render(os.Stdout)
+			}
+			return nil
+		},
+	}
+}
+
+func loadBenchmark(filename string) (*horologium.Benchmark, error) {
+	reader, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	var benchmark *horologium.Benchmark
+	if strings.EqualFold(filepath.Ext(filename), ".csv") {
+		benchmark, err = horologium.LoadBenchmarkFromCSV(reader, strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)))
+	} else {
+		benchmark, err = horologium.LoadBenchmarkFromReader(reader)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not load %s: %v", filename, err)
+	}
+	if benchmark.Name == "" {
+		benchmark.Name = filename
+	}
+	return benchmark, nil
+}
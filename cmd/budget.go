@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"github.com/urfave/cli/v2"
+	"os"
+	"time"
+)
+
+func budgetCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "budget",
+		Usage:     "Compares the projected costs of the current month with the budget; exits with status 1 if a budget is exceeded.",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Action: func(context *cli.Context) error {
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			exceeded := 0
+			for _, series := range portfolio {
+				forecast := series.ForecastBudget(time.Now())
+				if forecast == nil {
+					fmt.Printf("%s: no budget\n", series.Name)
+					continue
+				}
+				fmt.Printf("%s: ", series.Name)
+				forecast.Render(os.Stdout, series.CurrencyFormat)
+				if forecast.Exceeded() {
+					exceeded = exceeded + 1
+				}
+			}
+			if exceeded > 0 {
+				return cli.Exit(fmt.Sprintf("%d budget(s) exceeded", exceeded), 1)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fafeitsch/Horologium/horologium"
+	"github.com/urfave/cli/v2"
+	"math"
+	"os"
+	"time"
+)
+
+func checkCommand() *cli.Command {
+	var threshold float64
+	thresholdFlag := cli.Float64Flag{Name: "threshold", Value: horologium.DefaultAnomalyThreshold, Usage: "The z-score from which the daily consumption between two readings is reported as anomaly.", Destination: &threshold}
+	var months int
+	monthsFlag := cli.IntFlag{Name: "lastMonths", Value: 1, Usage: "Only report anomalies ending in the last months.", Destination: &months}
+	return &cli.Command{
+		Name:      "check",
+		Usage:     "Reports unusual consumption (e.g. leaks) compared with earlier readings of the same season; exits with status 1 if anomalies are found.",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Flags:     []cli.Flag{&thresholdFlag, &monthsFlag},
+		Action: func(context *cli.Context) error {
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			since := time.Now().AddDate(0, int(-math.Abs(float64(months))), 0)
+			found := 0
+			for _, series := range portfolio {
+				anomalies := series.Anomalies(threshold).Since(since)
+				if len(anomalies) == 0 {
+					continue
+				}
+				if found > 0 {
+					fmt.Println()
+				}
+				fmt.Printf("%s\n\n", series.Name)
+				anomalies.RenderTable(os.Stdout)
+				found = found + len(anomalies)
+			}
+			if found > 0 {
+				return cli.Exit(fmt.Sprintf("\n%d anomal(y/ies) found", found), 1)
+			}
+			return nil
+		},
+	}
+}
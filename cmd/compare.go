@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fafeitsch/Horologium/horologium"
+	"github.com/urfave/cli/v2"
+	"math"
+	"os"
+	"time"
+)
+
+func compareTariffsCommand() *cli.Command {
+	var months int
+	monthsFlag := cli.IntFlag{Name: "lastMonths", Value: 12, Usage: "The number of last full months to replay against the tariffs.", Destination: &months}
+	return &cli.Command{
+		Name:      "compare-tariffs",
+		Usage:     "Computes what the last months would have cost under alternative tariffs.",
+		ArgsUsage: "DATA_FILE TARIFF_FILE...",
+		Flags:     []cli.Flag{&monthsFlag},
+		Action: func(context *cli.Context) error {
+			if context.NArg() < 2 {
+				return fmt.Errorf("a data file and at least one tariff file are needed")
+			}
+			series, err := loadSeries(context.Args().First())
+			if err != nil {
+				return err
+			}
+			tariffs := []horologium.Tariff{{Name: "current (" + series.Name + ")", PricingPlans: series.PricingPlans,
+				OneTimeCharges: series.OneTimeCharges, PriceCaps: series.PriceCaps, ReliefCredits: series.ReliefCredits}}
+			for _, filename := range context.Args().Tail() {
+				tariff, err := loadTariff(filename)
+				if err != nil {
+					return err
+				}
+				tariffs = append(tariffs, *tariff)
+			}
+			now := time.Now()
+			end := horologium.CreateDate(now.Year(), int(now.Month()), 1)
+			start := end.AddDate(0, int(-math.Abs(float64(months))), 0)
+			comparisons := series.CompareTariffs(tariffs, start, end)
+			consumption := horologium.Statistics{ConsumptionFormat: series.ConsumptionFormat}
+			if len(comparisons) > 0 {
+				consumption.Consumption = comparisons[0].Consumption
+			}
+			fmt.Printf("Consumption from %s to %s: %s\n\n", start.Format(horologium.DateFormat), end.Format(horologium.DateFormat), consumption.FormatConsumption())
+			comparisons.RenderTable(os.Stdout, series.ConsumptionFormat, series.CurrencyFormat)
+			return nil
+		},
+	}
+}
+
+func loadTariff(filename string) (*horologium.Tariff, error) {
+	reader, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	tariff, err := horologium.LoadTariffFromReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not load %s: %v", filename, err)
+	}
+	if tariff.Name == "" {
+		tariff.Name = filename
+	}
+	return tariff, nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fafeitsch/Horologium/horologium"
+	"github.com/urfave/cli/v2"
+	"os"
+	"time"
+)
+
+func contractsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "contracts",
+		Usage:     "Shows the current contract of every series with the latest possible cancellation date.",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Action: func(context *cli.Context) error {
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			statuses := portfolio.Contracts(horologium.CreateDate(now.Year(), int(now.Month()), now.Day()))
+			if len(statuses) == 0 {
+				fmt.Println("no contracts given")
+				return nil
+			}
+			statuses.RenderTable(os.Stdout)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fafeitsch/Horologium/horologium"
+	"github.com/urfave/cli/v2"
+	"os"
+	"time"
+)
+
+func dueCommand() *cli.Command {
+	var days int
+	daysFlag := cli.IntFlag{Name: "days", Value: 3, Usage: "Also list readings that are due within the next days.", Destination: &days}
+	return &cli.Command{
+		Name:      "due",
+		Usage:     "Lists the series whose next meter reading is overdue or due soon (see readingInterval).",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Flags:     []cli.Flag{&daysFlag},
+		Action: func(context *cli.Context) error {
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			today := horologium.CreateDate(now.Year(), int(now.Month()), now.Day())
+			reminders := portfolio.DueReadings(today, days)
+			if len(reminders) == 0 {
+				fmt.Println("no readings due")
+				return nil
+			}
+			reminders.RenderTable(os.Stdout, today)
+			return nil
+		},
+	}
+}
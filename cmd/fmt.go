@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/fafeitsch/Horologium/horologium"
+	"github.com/urfave/cli/v2"
+	"io/ioutil"
+	"os"
+)
+
+func fmtCommand() *cli.Command {
+	var check bool
+	checkFlag := cli.BoolFlag{Name: "check", Usage: "Do not rewrite the files but fail if one of them is not formatted canonically.", Destination: &check}
+	return &cli.Command{
+		Name:      "fmt",
+		Usage:     "Rewrites data files in the canonical layout (sorted readings and plans, normalized dates).",
+		ArgsUsage: "DATA_FILE...",
+		Flags:     []cli.Flag{&checkFlag},
+		Action: func(context *cli.Context) error {
+			if context.NArg() == 0 {
+				return fmt.Errorf("no data file given")
+			}
+			unformatted := 0
+			for _, filename := range context.Args().Slice() {
+				original, err := ioutil.ReadFile(filename)
+				if err != nil {
+					return err
+				}
+				formatted := new(bytes.Buffer)
+				err = horologium.Format(bytes.NewReader(original), formatted)
+				if err != nil {
+					return fmt.Errorf("could not format %s: %v", filename, err)
+				}
+				if bytes.Equal(original, formatted.Bytes()) {
+					continue
+				}
+				if check {
+					fmt.Println(filename)
+					unformatted = unformatted + 1
+					continue
+				}
+				info, err := os.Stat(filename)
+				if err != nil {
+					return err
+				}
+				err = ioutil.WriteFile(filename, formatted.Bytes(), info.Mode())
+				if err != nil {
+					return err
+				}
+			}
+			if unformatted > 0 {
+				return cli.Exit(fmt.Sprintf("%d file(s) not formatted canonically", unformatted), 1)
+			}
+			return nil
+		},
+	}
+}
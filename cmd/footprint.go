@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fafeitsch/Horologium/horologium"
+	"github.com/urfave/cli/v2"
+	"os"
+	"time"
+)
+
+func footprintCommand() *cli.Command {
+	var years int
+	yearsFlag := cli.IntFlag{Name: "lastYears", Value: 3, Usage: "The number of calendar years to show (including the current year).", Destination: &years}
+	return &cli.Command{
+		Name:      "footprint",
+		Usage:     "Shows the yearly CO₂ emissions and primary energy of the consumption.",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Flags:     []cli.Flag{&yearsFlag},
+		Action: func(context *cli.Context) error {
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			start := horologium.CreateDate(now.Year()-years+1, 1, 1)
+			for index, series := range portfolio {
+				if index > 0 {
+					fmt.Println()
+				}
+				if len(portfolio) > 1 {
+					fmt.Printf("%s\n\n", series.Name)
+				}
+				if len(series.EmissionFactors) == 0 {
+					fmt.Println("no emission factors given")
+					continue
+				}
+				series.MonthlyStatistics(start, now).ByYear().RenderFootprintTable(os.Stdout)
+			}
+			return nil
+		},
+	}
+}
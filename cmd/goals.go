@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fafeitsch/Horologium/horologium"
+	"github.com/urfave/cli/v2"
+	"os"
+	"time"
+)
+
+func goalsCommand() *cli.Command {
+	var dateString string
+	dateFlag := cli.StringFlag{Name: "date", Usage: "The date of the progress report (defaults to today).", Destination: &dateString}
+	return &cli.Command{
+		Name:      "goals",
+		Usage:     "Shows the progress towards the consumption goals of the series.",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Flags:     []cli.Flag{&dateFlag},
+		Action: func(context *cli.Context) error {
+			date := time.Now()
+			if dateString != "" {
+				parsed, err := time.Parse(horologium.DateFormat, dateString)
+				if err != nil {
+					return fmt.Errorf("could not parse date: %v", err)
+				}
+				date = parsed
+			}
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			printed := false
+			for _, series := range portfolio {
+				for _, goal := range series.Goals {
+					if printed {
+						fmt.Println()
+					}
+					printed = true
+					title := series.Name
+					if goal.Name != "" {
+						title = title + ": " + goal.Name
+					}
+					progress, err := series.Progress(goal, date)
+					if err != nil {
+						fmt.Printf("%s: %v\n", title, err)
+						continue
+					}
+					fmt.Printf("%s\n\n", title)
+					progress.Render(os.Stdout)
+				}
+			}
+			if !printed {
+				fmt.Println("no goals given")
+			}
+			return nil
+		},
+	}
+}
@@ -2,44 +2,106 @@
 package main
 
 import (
+	"fmt"
 	"github.com/fafeitsch/Horologium/horologium"
 	"github.com/urfave/cli/v2"
+	"io/ioutil"
 	"log"
 	"math"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
+// The global options for the degree days, used by every loaded portfolio (see loadPortfolio).
+var (
+	temperaturesFile string
+	heatingLimit     float64
+)
+
 func main() {
 	var months int
 	monthsFlag := cli.IntFlag{Name: "lastMonths", Value: 6, Usage: "The number of last full months to show in the statistics (excluding the current month).", Destination: &months}
+	var currency string
+	currencyFlag := cli.StringFlag{Name: "currency", Usage: "The currency code (e.g. EUR) into which all costs are converted.", Destination: &currency}
+	var exchangeRatesFile string
+	var consumptionUnit string
+	consumptionUnitFlag := cli.StringFlag{Name: "consumptionUnit", Usage: "The unit (e.g. kWh) in which the total consumption of all series is shown in the overview.", Destination: &consumptionUnit}
+	temperaturesFlag := cli.StringFlag{Name: "temperatures", Usage: "A CSV file with lines DATE,MEAN_TEMPERATURE used for the degree days of heating series.", Destination: &temperaturesFile}
+	heatingLimitFlag := cli.Float64Flag{Name: "heatingLimit", Value: horologium.DefaultHeatingLimit, Usage: "The daily mean temperature (°C) below which degree days are counted.", Destination: &heatingLimit}
+	exchangeRatesFlag := cli.StringFlag{Name: "exchangeRates", Usage: "A CSV file with lines DATE,FROM,TO,RATE used for converting costs into the currency.", Destination: &exchangeRatesFile}
 	app := cli.App{
-		Name:                 "Horologium",
-		Description:          "Horologium reads consumption files and reports the consumption as well as the generated costs on a monthly basis.",
-		Authors:              []*cli.Author{{Name: "Fabian Feitsch", Email: "info@fafeitsch.de"}},
-		Copyright:            "MIT License",
-		Usage:                "horologium [OPTIONS] DATA_FILE",
-		Version:              "1.1.0",
-		Commands:             []*cli.Command{},
+		Name:        "Horologium",
+		Description: "Horologium reads consumption files and reports the consumption as well as the generated costs on a monthly basis.",
+		Authors:     []*cli.Author{{Name: "Fabian Feitsch", Email: "info@fafeitsch.de"}},
+		Copyright:   "MIT License",
+		Usage:       "horologium [OPTIONS] DATA_FILE|DIRECTORY...",
+		Version:     "1.1.0",
+		Commands: []*cli.Command{
+			fmtCommand(),
+			settlementCommand(),
+			recommendInstallmentCommand(),
+			compareTariffsCommand(),
+			footprintCommand(),
+			checkCommand(),
+			budgetCommand(),
+			goalsCommand(),
+			dueCommand(),
+			icalCommand(),
+			contractsCommand(),
+			metersCommand(),
+			readingsCommand(),
+			benchmarkCommand(),
+		},
 		EnableBashCompletion: true,
-		Flags:                []cli.Flag{&monthsFlag},
+		Flags:                []cli.Flag{&monthsFlag, &currencyFlag, &exchangeRatesFlag, &consumptionUnitFlag, &temperaturesFlag, &heatingLimitFlag},
 		Action: func(context *cli.Context) error {
-			filename := context.Args().Get(0)
-			reader, err := os.Open(filename)
-			if err != nil {
-				return err
-			}
-			defer func() {
-				_ = reader.Close()
-			}()
-			series, err := horologium.LoadFromReader(reader)
+			portfolio, err := loadPortfolio(context.Args().Slice())
 			if err != nil {
 				return err
 			}
 			beforeMonths := time.Now().AddDate(0, int(-math.Abs(float64(months))), 0)
 			start := horologium.CreateDate(beforeMonths.Year(), int(beforeMonths.Month()), 1)
-			stats := series.MonthlyStatistics(start, time.Now())
+			stats := portfolio.MonthlyStatistics(start, time.Now())
+			if currency != "" {
+				rates, err := loadExchangeRates(exchangeRatesFile)
+				if err != nil {
+					return err
+				}
+				stats, err = stats.Convert(rates, strings.ToUpper(currency))
+				if err != nil {
+					return err
+				}
+			} else if currencies := stats.Currencies(); len(currencies) > 1 {
+				return fmt.Errorf("the series are billed in different currencies (%s), choose a reporting currency with --currency", strings.Join(currencies, ", "))
+			}
+			if len(portfolio) == 1 {
+				stats.Statistics[0].RenderTable(os.Stdout)
+				printWarnings(portfolio[0], start, time.Now())
+				return nil
+			}
+			for index, series := range stats.Statistics {
+				fmt.Printf("%s\n\n", stats.Names[index])
+				series.RenderTable(os.Stdout)
+				printWarnings(portfolio[index], start, time.Now())
+				fmt.Println()
+			}
+			fmt.Printf("Overview\n\n")
 			stats.RenderTable(os.Stdout)
+			if consumptionUnit == "" {
+				return nil
+			}
+			unit, err := horologium.ParseUnit(consumptionUnit)
+			if err != nil {
+				return err
+			}
+			total, err := stats.TotalConsumption(unit)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nTotal consumption: %.2f %s\n", total, unit)
 			return nil
 		},
 	}
@@ -48,3 +110,127 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// loadPortfolio loads all series given as arguments. An argument may either be a data file
+// or a directory, in which case all yaml files of the directory are loaded.
+// If temperatures are given (--temperatures), the series use their degree days (see Series.UseDegreeDays).
+func loadPortfolio(args []string) (horologium.Portfolio, error) {
+	filenames, err := dataFiles(args)
+	if err != nil {
+		return nil, err
+	}
+	portfolio := make(horologium.Portfolio, 0, len(filenames))
+	for _, filename := range filenames {
+		series, err := loadSeries(filename)
+		if err != nil {
+			return nil, err
+		}
+		portfolio = append(portfolio, series)
+	}
+	if temperaturesFile != "" {
+		temperatures, err := loadTemperatures(temperaturesFile)
+		if err != nil {
+			return nil, err
+		}
+		portfolio.UseDegreeDays(horologium.NewDegreeDays(temperatures, heatingLimit))
+	}
+	return portfolio, nil
+}
+
+func dataFiles(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no data file given")
+	}
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			result = append(result, arg)
+			continue
+		}
+		entries, err := ioutil.ReadDir(arg)
+		if err != nil {
+			return nil, err
+		}
+		files := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			extension := strings.ToLower(filepath.Ext(entry.Name()))
+			if !entry.IsDir() && (extension == ".yml" || extension == ".yaml") {
+				files = append(files, filepath.Join(arg, entry.Name()))
+			}
+		}
+		sort.Strings(files)
+		result = append(result, files...)
+	}
+	return result, nil
+}
+
+func loadSeries(filename string) (*horologium.Series, error) {
+	reader, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	series, err := horologium.LoadFromReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not load %s: %v", filename, err)
+	}
+	if series.Name == "" {
+		series.Name = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	return series, nil
+}
+
+// printWarnings warns about gaps in the meter readings of the series between start and end,
+// where the statistics are interpolated over a long time, and about readings taken on meters with expired calibration.
+func printWarnings(series *horologium.Series, start time.Time, end time.Time) {
+	for _, gap := range series.Gaps(start, end) {
+		fmt.Printf("Warning: %s\n", gap)
+	}
+	for _, warning := range series.CalibrationWarnings() {
+		if !warning.Reading.Date.Before(start) && warning.Reading.Date.Before(end) {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+	}
+}
+
+// loadExchangeRates loads the exchange-rate table from the file. If no file is given,
+// the table is empty and only series in the reporting currency can be converted.
+func loadExchangeRates(filename string) (horologium.ExchangeRates, error) {
+	if filename == "" {
+		return horologium.ExchangeRates{}, nil
+	}
+	reader, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	rates, err := horologium.LoadExchangeRatesFromReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not load %s: %v", filename, err)
+	}
+	return rates, nil
+}
+
+// loadTemperatures loads the daily mean temperatures from the file.
+func loadTemperatures(filename string) (horologium.Temperatures, error) {
+	reader, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	temperatures, err := horologium.LoadTemperaturesFromReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not load %s: %v", filename, err)
+	}
+	return temperatures, nil
+}
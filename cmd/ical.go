@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/urfave/cli/v2"
+	"os"
+	"time"
+)
+
+func icalCommand() *cli.Command {
+	var output string
+	outputFlag := cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "The .ics file to write (defaults to the standard output).", Destination: &output}
+	return &cli.Command{
+		Name:      "ical",
+		Usage:     "Writes an iCalendar file with reminders to read the meters, the ends of pricing plans, and contract deadlines.",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Flags:     []cli.Flag{&outputFlag},
+		Action: func(context *cli.Context) error {
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				return portfolio.WriteCalendar(os.Stdout, time.Now())
+			}
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			err = portfolio.WriteCalendar(file, time.Now())
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			return err
+		},
+	}
+}
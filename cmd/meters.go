@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fafeitsch/Horologium/horologium"
+	"github.com/urfave/cli/v2"
+	"os"
+	"time"
+)
+
+func metersCommand() *cli.Command {
+	var days int
+	daysFlag := cli.IntFlag{Name: "days", Value: 365, Usage: "List meters whose calibration expires within the next days.", Destination: &days}
+	return &cli.Command{
+		Name:      "meters",
+		Usage:     "Lists the current meters whose calibration has expired or expires soon.",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Flags:     []cli.Flag{&daysFlag},
+		Action: func(context *cli.Context) error {
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			today := horologium.CreateDate(now.Year(), int(now.Month()), now.Day())
+			statuses := portfolio.ExpiringMeters(today, days)
+			if len(statuses) == 0 {
+				fmt.Println("no calibrations expiring")
+				return nil
+			}
+			statuses.RenderTable(os.Stdout, today)
+			return nil
+		},
+	}
+}
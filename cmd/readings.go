@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fafeitsch/Horologium/horologium"
+	"github.com/urfave/cli/v2"
+	"os"
+)
+
+func readingsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "readings",
+		Usage:     "Lists the meter readings of every series with their source (actual, estimated, provider, imported) and note.",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Action: func(context *cli.Context) error {
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			for index, series := range portfolio {
+				if index > 0 {
+					fmt.Println()
+				}
+				fmt.Printf("%s\n\n", series.Name)
+				readings := append(horologium.MeterReadings{}, series.MeterReadings...)
+				readings.Sort()
+				if len(readings) == 0 {
+					fmt.Println("no readings given")
+					continue
+				}
+				readings.RenderTable(os.Stdout)
+			}
+			return nil
+		},
+	}
+}
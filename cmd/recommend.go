@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fafeitsch/Horologium/horologium"
+	"github.com/urfave/cli/v2"
+	"time"
+)
+
+func recommendInstallmentCommand() *cli.Command {
+	var margin float64
+	var date string
+	marginFlag := cli.Float64Flag{Name: "margin", Value: 5, Usage: "The safety margin in percent added to the installment.", Destination: &margin}
+	dateFlag := cli.StringFlag{Name: "date", Usage: "The start of the next billing year (defaults to the first day of the next month).", Destination: &date}
+	return &cli.Command{
+		Name:      "recommend-installment",
+		Usage:     "Suggests the monthly installment for the next billing year based on the last twelve months.",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Flags:     []cli.Flag{&marginFlag, &dateFlag},
+		Action: func(context *cli.Context) error {
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			nextMonth := time.Now().AddDate(0, 1, 0)
+			start := horologium.CreateDate(nextMonth.Year(), int(nextMonth.Month()), 1)
+			if date != "" {
+				start, err = time.Parse(horologium.DateFormat, date)
+				if err != nil {
+					return fmt.Errorf("could not parse date: %v", err)
+				}
+			}
+			for _, series := range portfolio {
+				recommendation, err := series.RecommendInstallment(start, margin/100)
+				if err != nil {
+					return fmt.Errorf("could not recommend an installment for %s: %v", series.Name, err)
+				}
+				consumption := horologium.Statistics{Consumption: recommendation.Consumption, ConsumptionFormat: series.ConsumptionFormat}
+				costs := horologium.Statistics{Costs: recommendation.Costs, CurrencyFormat: series.CurrencyFormat}
+				installment := horologium.Statistics{Costs: recommendation.Installment, CurrencyFormat: series.CurrencyFormat}
+				balance := ""
+				if recommendation.Balance != 0 {
+					stat := horologium.Statistics{Costs: recommendation.Balance, CurrencyFormat: series.CurrencyFormat}
+					balance = ", balance so far " + stat.FormatCosts()
+				}
+				fmt.Printf("%s: expected consumption %s, expected costs %s (plan %s)%s, recommended installment %s per month\n",
+					series.Name, consumption.FormatConsumption(), costs.FormatCosts(), recommendation.Plan.Name, balance, installment.FormatCosts())
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"github.com/urfave/cli/v2"
+	"os"
+	"time"
+)
+
+func settlementCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "settlement",
+		Usage:     "Compares the advance payments of every billing period with the computed costs and shows the expected refund.",
+		ArgsUsage: "DATA_FILE|DIRECTORY...",
+		Action: func(context *cli.Context) error {
+			portfolio, err := loadPortfolio(context.Args().Slice())
+			if err != nil {
+				return err
+			}
+			for index, series := range portfolio {
+				if index > 0 {
+					fmt.Println()
+				}
+				if len(portfolio) > 1 {
+					fmt.Printf("%s\n\n", series.Name)
+				}
+				series.Settlements(time.Now()).RenderTable(os.Stdout)
+			}
+			return nil
+		},
+	}
+}
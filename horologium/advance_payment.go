@@ -0,0 +1,248 @@
+package horologium
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// AdvancePayment is a fixed monthly installment paid to the utility. The time frame in which the
+// installment is valid is the billing period: at its end, the utility settles the difference
+// between the sum of installments and the actual costs.
+type AdvancePayment struct {
+	Amount    Money      // the installment paid at the first day of every month
+	ValidFrom time.Time  // the start of the billing period
+	ValidTo   *time.Time // the end of the billing period (exclusive); if nil, the billing period lasts one year
+}
+
+// End returns the end of the billing period of the advance payment (exclusive).
+func (a *AdvancePayment) End() time.Time {
+	if a.ValidTo != nil {
+		return *a.ValidTo
+	}
+	return a.ValidFrom.AddDate(1, 0, 0)
+}
+
+// Payments returns the sum of installments paid between start (inclusive) and end (exclusive).
+// Only installments in the billing period of the advance payment are considered.
+func (a *AdvancePayment) Payments(start time.Time, end time.Time) Money {
+	if start.Before(a.ValidFrom) {
+		start = a.ValidFrom
+	}
+	if a.End().Before(end) {
+		end = a.End()
+	}
+	return a.Amount * Money(firstDaysBetween(start, end))
+}
+
+// AdvancePayments is a slice of advance payments.
+type AdvancePayments []AdvancePayment
+
+// Sort sorts the advance payments in ascending order by their ValidFrom date.
+func (a AdvancePayments) Sort() {
+	sort.SliceStable(a, func(i, j int) bool {
+		return a[i].ValidFrom.Before(a[j].ValidFrom)
+	})
+}
+
+func (a AdvancePayments) validAt(date time.Time) *AdvancePayment {
+	for index := range a {
+		if !date.Before(a[index].ValidFrom) && date.Before(a[index].End()) {
+			return &a[index]
+		}
+	}
+	return nil
+}
+
+// firstDaysBetween returns the number of first days of a month between start (inclusive) and end (exclusive).
+func firstDaysBetween(start time.Time, end time.Time) int {
+	if !start.Before(end) {
+		return 0
+	}
+	result := monthsBetween(start, end)
+	if start.Day() != 1 {
+		result = result - 1
+	}
+	return result
+}
+
+// Settlement compares the advance payments of a billing period with the costs computed for the same period.
+type Settlement struct {
+	ValidFrom      time.Time // the start of the billing period
+	ValidTo        time.Time // the end of the billing period (exclusive)
+	Payments       Money     // the sum of all installments of the billing period
+	Costs          Money     // the costs of the billing period
+	Projected      bool      // true if the billing period is not over yet and the costs are extrapolated
+	CurrencyFormat string    // the format used for the currency, e.g. %.2f Euro
+}
+
+// Balance returns the expected result of the settlement: a positive value means
+// that the utility refunds money, a negative value means an additional payment.
+func (s *Settlement) Balance() Money {
+	return s.Payments - s.Costs
+}
+
+// Settlements computes the settlement of every billing period that started before the given date.
+//
+// If a billing period is not over at the given date, its costs are extrapolated
+// from the costs so far, assuming they continue to increase at the same daily rate.
+func (s *Series) Settlements(date time.Time) Settlements {
+	result := make(Settlements, 0, len(s.AdvancePayments))
+	for _, payment := range s.AdvancePayments {
+		if !payment.ValidFrom.Before(date) {
+			continue
+		}
+		settlement := Settlement{
+			ValidFrom:      payment.ValidFrom,
+			ValidTo:        payment.End(),
+			Payments:       payment.Payments(payment.ValidFrom, payment.End()),
+			CurrencyFormat: s.CurrencyFormat,
+		}
+		if date.Before(settlement.ValidTo) {
+			costs, _ := s.CostsAndConsumption(settlement.ValidFrom, date)
+			elapsed := date.Sub(settlement.ValidFrom).Hours()
+			total := settlement.ValidTo.Sub(settlement.ValidFrom).Hours()
+			settlement.Costs = s.Rounding.round(RoundFinal, costs.Multiply(total/elapsed))
+			settlement.Projected = true
+		} else {
+			settlement.Costs, _ = s.CostsAndConsumption(settlement.ValidFrom, settlement.ValidTo)
+		}
+		result = append(result, settlement)
+	}
+	return result
+}
+
+// Settlements is a slice of settlements.
+type Settlements []Settlement
+
+// RenderTable renders the settlements as a table with one row per billing period. Projected settlements
+// are marked with an asterisk.
+func (s Settlements) RenderTable(writer io.Writer) {
+	columns := []tableColumn{{header: "FROM", left: true}, {header: "TO", left: true}, {header: "PAYMENTS"}, {header: "COSTS"}, {header: "REFUND"}}
+	rows := make([][]string, 0, len(s))
+	for _, settlement := range s {
+		to := settlement.ValidTo.Format(DateFormat)
+		if settlement.Projected {
+			to = to + " *"
+		}
+		format := func(value Money) string {
+			stat := Statistics{Costs: value, CurrencyFormat: settlement.CurrencyFormat}
+			return stat.FormatCosts()
+		}
+		rows = append(rows, []string{settlement.ValidFrom.Format(DateFormat), to, format(settlement.Payments), format(settlement.Costs), format(settlement.Balance())})
+	}
+	renderTable(writer, columns, rows, nil)
+	for _, settlement := range s {
+		if settlement.Projected {
+			_, _ = fmt.Fprintln(writer, "* billing period not over yet, costs are extrapolated")
+			return
+		}
+	}
+}
+
+// InstallmentRecommendation is the result of Series.RecommendInstallment.
+type InstallmentRecommendation struct {
+	Plan           PricingPlan // the pricing plan the recommendation is based on
+	Consumption    float64     // the expected consumption of the next twelve months
+	Costs          Money       // the expected costs of the next twelve months, including relief and one-time charges
+	Relief         Money       // the expected relief of the next twelve months by price caps and relief credits
+	OneTimeCharges Money       // the one-time charges of the next twelve months
+	Balance        Money       // the balance of the billing period running at the date so far; positive if overpaid
+	Installment    Money       // the recommended monthly installment, including the safety margin
+}
+
+// RecommendInstallment suggests the monthly installment for the billing year starting at the given date,
+// such that the expected settlement at the end of the billing year is close to zero.
+//
+// The expected consumption is the consumption of the twelve months before the date (see MonthlyStatistics).
+// If the meter readings cover less than these twelve months, the consumption is extrapolated to a full year.
+// The expected costs are computed with the pricing plan valid at the given date, reduced by the relief of the
+// price caps and relief credits and increased by the one-time charges of the billing year. The expected consumption
+// is assumed to be distributed evenly over the year when applying the quota of a price cap. The margin is added on
+// top of the installment to be on the safe side, e.g. a margin of 0.05 increases the installment by 5 %.
+// If a billing period is running at the date, its balance so far (installments minus costs) is evened out by the
+// installments of the billing year; the margin does not apply to the balance.
+// Periods of excluded events (see Event) are left out before extrapolating the consumption.
+func (s *Series) RecommendInstallment(date time.Time, margin float64) (*InstallmentRecommendation, error) {
+	plan := s.PricingPlans.validAt(date)
+	if plan == nil {
+		return nil, fmt.Errorf("no pricing plan valid at %s", date.Format(DateFormat))
+	}
+	if len(s.MeterReadings) < 2 {
+		return nil, fmt.Errorf("at least two meter readings are needed")
+	}
+	start := date.AddDate(-1, 0, 0)
+	consumption, _ := s.MonthlyStatistics(start, date).Total()
+	coveredFrom := s.MeterReadings[0].Date
+	if coveredFrom.Before(start) {
+		coveredFrom = start
+	}
+	coveredTo := s.MeterReadings[len(s.MeterReadings)-1].Date
+	if coveredTo.After(date) {
+		coveredTo = date
+	}
+	if !coveredFrom.Before(coveredTo) {
+		return nil, fmt.Errorf("no meter readings in the twelve months before %s", date.Format(DateFormat))
+	}
+	covered := coveredTo.Sub(coveredFrom).Hours()
+	if s.Events.excludes(coveredFrom, coveredTo) {
+		consumption, covered = s.typicalConsumption(coveredFrom, coveredTo)
+		if covered == 0 {
+			return nil, fmt.Errorf("all meter readings in the twelve months before %s are excluded by events", date.Format(DateFormat))
+		}
+	}
+	consumption = consumption * date.Sub(start).Hours() / covered
+	end := date.AddDate(1, 0, 0)
+	relief := s.expectedRelief(plan, consumption, date, end)
+	charges := s.oneTimeCharges(date, end)
+	costs := plan.UnitPrice.Multiply(consumption) + 12*plan.BasePrice - relief + charges
+	balance := Money(0)
+	if payment := s.AdvancePayments.validAt(date); payment != nil && payment.ValidFrom.Before(date) {
+		costsSoFar, _ := s.CostsAndConsumption(payment.ValidFrom, date)
+		balance = payment.Payments(payment.ValidFrom, date) - costsSoFar
+	}
+	installment := costs.Multiply((1+margin)/12) - balance.Multiply(1.0/12)
+	if installment < 0 {
+		installment = 0
+	}
+	return &InstallmentRecommendation{
+		Plan:           *plan,
+		Consumption:    consumption,
+		Costs:          costs,
+		Relief:         relief,
+		OneTimeCharges: charges,
+		Balance:        balance,
+		Installment:    installment,
+	}, nil
+}
+
+// expectedRelief returns the relief between start and end by the price caps and relief credits of the series,
+// assuming that the consumption of the year is distributed evenly (see Series.Relief).
+func (s *Series) expectedRelief(plan *PricingPlan, consumption float64, start time.Time, end time.Time) Money {
+	relief := Money(0)
+	year := end.Sub(start).Hours()
+	for _, priceCap := range s.PriceCaps {
+		capStart, capEnd := priceCap.ValidFrom, end
+		if capStart.Before(start) {
+			capStart = start
+		}
+		if priceCap.ValidTo != nil && priceCap.ValidTo.Before(end) {
+			capEnd = *priceCap.ValidTo
+		}
+		if !capStart.Before(capEnd) || plan.UnitPrice <= priceCap.CappedPrice {
+			continue
+		}
+		days := math.Round(capEnd.Sub(capStart).Hours() / 24)
+		quota := priceCap.ReferenceConsumption * priceCap.Quota * days / 365
+		capped := math.Min(consumption*capEnd.Sub(capStart).Hours()/year, quota)
+		relief = relief + s.Rounding.round(RoundLineItem, (plan.UnitPrice-priceCap.CappedPrice).Multiply(capped))
+	}
+	for _, credit := range s.ReliefCredits {
+		if !credit.Date.Before(start) && credit.Date.Before(end) {
+			relief = relief + s.Rounding.round(RoundLineItem, credit.Amount)
+		}
+	}
+	return relief
+}
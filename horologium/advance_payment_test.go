@@ -0,0 +1,161 @@
+package horologium
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPaymentSeries() *Series {
+	// 100 units per month, each costing 1 plus a base price of 5
+	return &Series{
+		PricingPlans: PricingPlans{{BasePrice: NewMoney(5), UnitPrice: NewMoney(1)}},
+		MeterReadings: MeterReadings{
+			{Date: CreateDate(2020, 1, 1), Count: 0},
+			{Date: CreateDate(2020, 2, 1), Count: 100},
+			{Date: CreateDate(2020, 3, 1), Count: 200},
+			{Date: CreateDate(2020, 4, 1), Count: 300},
+			{Date: CreateDate(2020, 5, 1), Count: 400},
+		},
+		AdvancePayments: AdvancePayments{
+			{Amount: NewMoney(100), ValidFrom: CreateDate(2020, 1, 1), ValidTo: formatDatePtr(2020, 3, 1)},
+			{Amount: NewMoney(110), ValidFrom: CreateDate(2020, 3, 1), ValidTo: formatDatePtr(2020, 7, 1)},
+		},
+	}
+}
+
+func TestFirstDaysBetween(t *testing.T) {
+	tests := []struct {
+		start string
+		end   string
+		want  int
+	}{
+		{start: "2020-01-01", end: "2020-04-01", want: 3},
+		{start: "2020-01-15", end: "2020-04-01", want: 2},
+		{start: "2020-01-15", end: "2020-04-02", want: 3},
+		{start: "2020-01-15", end: "2020-01-20", want: 0},
+		{start: "2020-01-01", end: "2020-01-01", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s - %s", tt.start, tt.end), func(t *testing.T) {
+			start, _ := time.Parse(DateFormat, tt.start)
+			end, _ := time.Parse(DateFormat, tt.end)
+			assert.Equal(t, tt.want, firstDaysBetween(start, end), "number of first days is wrong")
+		})
+	}
+}
+
+func TestAdvancePayment_End(t *testing.T) {
+	payment := AdvancePayment{ValidFrom: CreateDate(2020, 3, 1)}
+	assert.Equal(t, CreateDate(2021, 3, 1), payment.End(), "billing period without end should last one year")
+	payment.ValidTo = formatDatePtr(2020, 9, 1)
+	assert.Equal(t, CreateDate(2020, 9, 1), payment.End(), "end of billing period is wrong")
+}
+
+func TestSeries_Settlements(t *testing.T) {
+	series := testPaymentSeries()
+	got := series.Settlements(CreateDate(2020, 5, 1))
+	require.Equal(t, 2, len(got), "there should be two settlements")
+	assert.Equal(t, Settlement{ValidFrom: CreateDate(2020, 1, 1), ValidTo: CreateDate(2020, 3, 1), Payments: NewMoney(200), Costs: NewMoney(210)}, got[0], "first settlement is wrong")
+	assert.Equal(t, NewMoney(-10), got[0].Balance(), "an additional payment of 10 is expected")
+	assert.True(t, got[1].Projected, "second billing period is not over yet")
+	assert.Equal(t, NewMoney(440), got[1].Payments, "payments of second billing period are wrong")
+	assert.InDelta(t, 210.0*122/61, got[1].Costs.Float(), 1e-6, "costs of second billing period should be extrapolated")
+}
+
+func TestSeries_MonthlyStatistics_Balance(t *testing.T) {
+	series := testPaymentSeries()
+	got := series.MonthlyStatistics(CreateDate(2020, 2, 1), CreateDate(2020, 5, 1))
+	require.Equal(t, 3, len(got), "there should be three months")
+	assert.Equal(t, NewMoney(100), got[0].Payments, "payments of February are wrong")
+	assert.Equal(t, NewMoney(-10), got[0].Balance, "balance should include January")
+	assert.Equal(t, NewMoney(110), got[1].Payments, "payments of March are wrong")
+	assert.Equal(t, NewMoney(5), got[1].Balance, "balance should restart with new billing period")
+	assert.Equal(t, NewMoney(10), got[2].Balance, "balance of April is wrong")
+}
+
+func ExampleSettlements_RenderTable() {
+	series := testPaymentSeries()
+	series.Settlements(CreateDate(2020, 5, 1)).RenderTable(os.Stdout)
+	// Output:
+	// |    FROM    |      TO      | PAYMENTS | COSTS  | REFUND |
+	// |------------|--------------|----------|--------|--------|
+	// | 2020-01-01 | 2020-03-01   |   200.00 | 210.00 | -10.00 |
+	// | 2020-03-01 | 2020-07-01 * |   440.00 | 420.00 |  20.00 |
+	// |------------|--------------|----------|--------|--------|
+	// * billing period not over yet, costs are extrapolated
+}
+
+func ExampleMonthlyStatistics_RenderTable_payments() {
+	series := testPaymentSeries()
+	series.MonthlyStatistics(CreateDate(2020, 1, 1), CreateDate(2020, 5, 1)).RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR | CONSUMPTION | COSTS  | PAYMENTS | BALANCE |
+	// |-----------|------|-------------|--------|----------|---------|
+	// | January   | 2020 |      100.00 | 105.00 |   100.00 |   -5.00 |
+	// | February  |      |      100.00 | 105.00 |   100.00 |  -10.00 |
+	// | March     |      |      100.00 | 105.00 |   110.00 |    5.00 |
+	// | April     |      |      100.00 | 105.00 |   110.00 |   10.00 |
+	// |-----------|------|-------------|--------|----------|---------|
+	// | TOTAL     |      |      400.00 | 420.00 |   420.00 |         |
+	// |-----------|------|-------------|--------|----------|---------|
+}
+
+func TestLoadFromReader_AdvancePayments(t *testing.T) {
+	file := `advancePayments:
+  - {amount: 85, validFrom: 2020-01-01, validTo: 2021-01-01}
+  - {amount: 90, validFrom: 2021-01-01}`
+	got, err := LoadFromReader(strings.NewReader(file))
+	require.NoError(t, err, "loading the file failed")
+	require.Equal(t, 2, len(got.AdvancePayments), "number of advance payments is wrong")
+	assert.Equal(t, AdvancePayment{Amount: NewMoney(85), ValidFrom: CreateDate(2020, 1, 1), ValidTo: formatDatePtr(2021, 1, 1)}, got.AdvancePayments[0], "first advance payment is wrong")
+	assert.Nil(t, got.AdvancePayments[1].ValidTo, "validTo of second advance payment should be nil")
+
+	_, err = LoadFromReader(strings.NewReader("advancePayments:\n  - {amount: 85, validFrom: 2020}"))
+	assert.EqualError(t, err, "could not parse advance payment 0: could not parse validFrom date: parsing time \"2020\" as \"2006-01-02\": cannot parse \"\" as \"-\"", "error message wrong")
+}
+
+func TestSeries_RecommendInstallment(t *testing.T) {
+	series := testPaymentSeries()
+	got, err := series.RecommendInstallment(CreateDate(2020, 5, 1), 0.1)
+	require.NoError(t, err, "recommendation failed")
+	// four months of readings with 100 units each are extrapolated to a full year
+	assert.InDelta(t, 400.0*366/121, got.Consumption, 1e-9, "consumption is wrong")
+	assert.InDelta(t, got.Consumption+60, got.Costs.Float(), 1e-6, "costs are wrong")
+	// 220 paid and 210 used since March 2020
+	assert.Equal(t, NewMoney(10), got.Balance, "balance is wrong")
+	assert.InDelta(t, got.Costs.Float()/12*1.1-10.0/12, got.Installment.Float(), 1e-6, "installment is wrong")
+
+	series.PricingPlans[0].ValidFrom = formatDatePtr(2021, 1, 1)
+	_, err = series.RecommendInstallment(CreateDate(2020, 5, 1), 0.1)
+	assert.EqualError(t, err, "no pricing plan valid at 2020-05-01", "error message wrong")
+}
+
+func TestSeries_RecommendInstallment_Relief(t *testing.T) {
+	series := testPaymentSeries()
+	series.AdvancePayments = nil
+	series.PriceCaps = PriceCaps{{Name: "Price brake", CappedPrice: NewMoney(0.5), Quota: 0.5, ReferenceConsumption: 1000, ValidFrom: CreateDate(2020, 1, 1)}}
+	series.ReliefCredits = ReliefCredits{{Name: "Relief", Amount: NewMoney(20), Date: CreateDate(2020, 12, 1)}}
+	series.OneTimeCharges = OneTimeCharges{{Name: "Meter fee", Amount: NewMoney(30), Date: CreateDate(2021, 1, 1)}}
+	got, err := series.RecommendInstallment(CreateDate(2020, 5, 1), 0)
+	require.NoError(t, err, "recommendation failed")
+	// the quota of 500 units is used up, each saving 0.5
+	assert.Equal(t, NewMoney(250+20), got.Relief, "relief is wrong")
+	assert.Equal(t, NewMoney(30), got.OneTimeCharges, "one-time charges are wrong")
+	assert.InDelta(t, got.Consumption+60-270+30, got.Costs.Float(), 1e-6, "costs should include relief and charges")
+	assert.Equal(t, Money(0), got.Balance, "without advance payments, there is no balance")
+	assert.InDelta(t, got.Costs.Float()/12, got.Installment.Float(), 1e-6, "installment is wrong")
+}
+
+func ExampleSeries_RecommendInstallment() {
+	plan := PricingPlan{BasePrice: NewMoney(10), UnitPrice: NewMoney(0.3)}
+	readings := MeterReadings{{Date: CreateDate(2019, 1, 1), Count: 1000}, {Date: CreateDate(2020, 1, 1), Count: 3000}}
+	series := Series{PricingPlans: PricingPlans{plan}, MeterReadings: readings}
+	recommendation, _ := series.RecommendInstallment(CreateDate(2020, 1, 1), 0.05)
+	fmt.Printf("Costs: %.2f, Installment: %.2f", recommendation.Costs, recommendation.Installment)
+	// Output: Costs: 720.00, Installment: 63.00
+}
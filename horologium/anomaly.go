@@ -0,0 +1,145 @@
+package horologium
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// DefaultAnomalyThreshold is the z-score from which a consumption rate is considered anomalous.
+const DefaultAnomalyThreshold = 3.0
+
+// minimumBaseline is the number of earlier periods of the same season needed to detect anomalies.
+const minimumBaseline = 3
+
+// minimumDeviation is the smallest standard deviation of a baseline relative to its mean. Baselines with
+// (almost) identical rates, e.g. from interpolated or estimated readings, would flag every small difference otherwise.
+const minimumDeviation = 0.05
+
+// Anomaly is a period between two consecutive meter readings whose daily consumption deviates strongly
+// from the daily consumption of earlier periods in the same season, e.g. because of a leak.
+type Anomaly struct {
+	Start     time.Time // the date of the first reading
+	End       time.Time // the date of the second reading
+	Rate      float64   // the daily consumption between the readings in the meter unit
+	Baseline  float64   // the average daily consumption of earlier periods in the same season
+	Deviation float64   // the z-score of the rate, i.e. by how many standard deviations it differs from the baseline
+}
+
+// Anomalies is a slice of anomalies.
+type Anomalies []Anomaly
+
+// Anomalies compares the daily consumption between every two consecutive readings with the baseline of that
+// season: all earlier periods whose middle is at most one calendar month apart from the middle of the period
+// (in any year). A period is anomalous if the absolute z-score of its rate reaches the threshold; both unusually
+// high (leaks) and unusually low (broken meters) consumptions are reported. Periods with less than three
+// earlier periods in the same season are not checked. The standard deviation of a baseline is at least 5 % of its
+// mean, so a rate has to differ by 15 % from a constant baseline to reach the default threshold; only if the
+// baseline has no consumption at all, any consumption is anomalous.
+func (m MeterReadings) Anomalies(threshold float64) Anomalies {
+	return m.anomalies(threshold, nil)
+}
+
+// Anomalies detects anomalies in the meter readings of the series (see MeterReadings.Anomalies). Periods overlapping
+// excluded events (see Event) are not part of the baselines, so e.g. a vacation does not make normal consumption anomalous.
+// If the series ignores estimates, estimated readings between other readings are skipped.
+func (s *Series) Anomalies(threshold float64) Anomalies {
+	readings := s.MeterReadings
+	if s.IgnoreEstimates {
+		sorted := append(MeterReadings{}, readings...)
+		sorted.Sort()
+		readings = sorted.withoutEstimates()
+	}
+	return readings.anomalies(threshold, s.Events)
+}
+
+func (m MeterReadings) anomalies(threshold float64, events Events) Anomalies {
+	sorted := append(MeterReadings{}, m...)
+	sorted.Sort()
+	type period struct {
+		start, end time.Time
+		rate       float64
+		month      int
+	}
+	periods := make([]period, 0, len(sorted))
+	for index := 0; index+1 < len(sorted); index++ {
+		start, end := sorted[index].Date, sorted[index+1].Date
+		days := math.Round(end.Sub(start).Hours() / 24)
+		if days <= 0 {
+			continue
+		}
+		middle := start.Add(end.Sub(start) / 2)
+		rate := (sorted[index+1].Count - sorted[index].Count) / days
+		periods = append(periods, period{start: start, end: end, rate: rate, month: int(middle.Month())})
+	}
+	result := make(Anomalies, 0)
+	for index, current := range periods {
+		baseline := make([]float64, 0, index)
+		for _, earlier := range periods[:index] {
+			if events.excludes(earlier.start, earlier.end) {
+				continue
+			}
+			distance := (current.month - earlier.month + 12) % 12
+			if distance <= 1 || distance == 11 {
+				baseline = append(baseline, earlier.rate)
+			}
+		}
+		if len(baseline) < minimumBaseline {
+			continue
+		}
+		mean, deviation := meanAndDeviation(baseline)
+		deviation = math.Max(deviation, minimumDeviation*math.Abs(mean))
+		zScore := 0.0
+		if deviation > 0 {
+			zScore = (current.rate - mean) / deviation
+		} else if current.rate != mean {
+			zScore = math.Copysign(math.Inf(1), current.rate-mean)
+		}
+		if math.Abs(zScore) >= threshold {
+			result = append(result, Anomaly{Start: current.start, End: current.end, Rate: current.rate, Baseline: mean, Deviation: zScore})
+		}
+	}
+	return result
+}
+
+// meanAndDeviation returns the mean and the sample standard deviation of the values.
+func meanAndDeviation(values []float64) (float64, float64) {
+	sum := 0.0
+	for _, value := range values {
+		sum = sum + value
+	}
+	mean := sum / float64(len(values))
+	squares := 0.0
+	for _, value := range values {
+		squares = squares + (value-mean)*(value-mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)-1))
+}
+
+// Since returns the anomalies ending on or after the date.
+func (a Anomalies) Since(date time.Time) Anomalies {
+	result := make(Anomalies, 0, len(a))
+	for _, anomaly := range a {
+		if !anomaly.End.Before(date) {
+			result = append(result, anomaly)
+		}
+	}
+	return result
+}
+
+// RenderTable renders the anomalies as table with the daily consumption, the baseline, and the z-score.
+func (a Anomalies) RenderTable(writer io.Writer) {
+	columns := []tableColumn{{header: "FROM", left: true}, {header: "TO", left: true}, {header: "PER DAY"}, {header: "BASELINE"}, {header: "Z-SCORE"}}
+	rows := make([][]string, 0, len(a))
+	for _, anomaly := range a {
+		rows = append(rows, []string{
+			anomaly.Start.Format(DateFormat),
+			anomaly.End.Format(DateFormat),
+			fmt.Sprintf("%.2f", anomaly.Rate),
+			fmt.Sprintf("%.2f", anomaly.Baseline),
+			fmt.Sprintf("%.1f", anomaly.Deviation),
+		})
+	}
+	renderTable(writer, columns, rows, nil)
+}
@@ -0,0 +1,81 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"os"
+	"testing"
+)
+
+func testAnomalyReadings() MeterReadings {
+	readings := MeterReadings{}
+	count := 0.0
+	for year := 2020; year <= 2022; year++ {
+		for month := 1; month <= 12; month++ {
+			readings = append(readings, MeterReading{Date: CreateDate(year, month, 1), Count: count})
+			count = count + 30 + float64(month%3)
+		}
+	}
+	return readings
+}
+
+func TestMeterReadings_Anomalies(t *testing.T) {
+	readings := testAnomalyReadings()
+	assert.Empty(t, readings.Anomalies(DefaultAnomalyThreshold), "regular consumption should not be anomalous")
+
+	readings = append(readings, MeterReading{Date: CreateDate(2023, 1, 1), Count: readings[len(readings)-1].Count + 300})
+	got := readings.Anomalies(DefaultAnomalyThreshold)
+	require.Equal(t, 1, len(got), "the leak should be detected")
+	assert.Equal(t, CreateDate(2022, 12, 1), got[0].Start, "start of the anomaly is wrong")
+	assert.Equal(t, CreateDate(2023, 1, 1), got[0].End, "end of the anomaly is wrong")
+	assert.InDelta(t, 300/31.0, got[0].Rate, 1e-9, "rate of the anomaly is wrong")
+	assert.Greater(t, got[0].Deviation, DefaultAnomalyThreshold, "z-score should exceed the threshold")
+	assert.Empty(t, got.Since(CreateDate(2023, 1, 2)), "older anomalies should be filtered")
+}
+
+func TestMeterReadings_Anomalies_ConstantBaseline(t *testing.T) {
+	readings := MeterReadings{
+		{Date: CreateDate(2020, 1, 1), Count: 0}, {Date: CreateDate(2020, 1, 11), Count: 10},
+		{Date: CreateDate(2020, 1, 21), Count: 20}, {Date: CreateDate(2020, 1, 31), Count: 30},
+		{Date: CreateDate(2020, 2, 10), Count: 30},
+	}
+	got := readings.Anomalies(DefaultAnomalyThreshold)
+	require.Equal(t, 1, len(got), "a broken meter should be detected with a constant baseline")
+	assert.Equal(t, 1.0, got[0].Baseline, "baseline is wrong")
+	assert.InDelta(t, -20, got[0].Deviation, 1e-9, "the deviation of a constant baseline should be 5 % of its mean")
+
+	readings[4].Count = 40.2
+	assert.Empty(t, readings.Anomalies(DefaultAnomalyThreshold), "small differences from a constant baseline should not be anomalous")
+
+	readings = MeterReadings{
+		{Date: CreateDate(2020, 1, 1), Count: 0}, {Date: CreateDate(2020, 1, 11), Count: 0},
+		{Date: CreateDate(2020, 1, 21), Count: 0}, {Date: CreateDate(2020, 1, 31), Count: 0},
+		{Date: CreateDate(2020, 2, 10), Count: 0.1},
+	}
+	got = readings.Anomalies(DefaultAnomalyThreshold)
+	require.Equal(t, 1, len(got), "any consumption should be detected if there was none before")
+	assert.True(t, math.IsInf(got[0].Deviation, 1), "z-score should be infinity")
+}
+
+func TestSeries_Anomalies_IgnoreEstimates(t *testing.T) {
+	readings := testAnomalyReadings()
+	// the provider overestimated December 2022 and corrected it with the next actual reading
+	readings = append(readings,
+		MeterReading{Date: CreateDate(2023, 1, 1), Count: readings[len(readings)-1].Count + 100, Source: ReadingEstimated},
+		MeterReading{Date: CreateDate(2023, 2, 1), Count: readings[len(readings)-1].Count + 61})
+	series := Series{MeterReadings: readings}
+	assert.NotEmpty(t, series.Anomalies(DefaultAnomalyThreshold), "the estimate should look like an anomaly")
+	series.IgnoreEstimates = true
+	assert.Empty(t, series.Anomalies(DefaultAnomalyThreshold), "ignored estimates should not create anomalies")
+}
+
+func ExampleAnomalies_RenderTable() {
+	anomalies := Anomalies{{Start: CreateDate(2022, 12, 1), End: CreateDate(2023, 1, 1), Rate: 9.68, Baseline: 1.02, Deviation: 21.3}}
+	anomalies.RenderTable(os.Stdout)
+	// Output:
+	// |    FROM    |     TO     | PER DAY | BASELINE | Z-SCORE |
+	// |------------|------------|---------|----------|---------|
+	// | 2022-12-01 | 2023-01-01 |    9.68 |     1.02 |    21.3 |
+	// |------------|------------|---------|----------|---------|
+}
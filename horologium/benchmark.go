@@ -0,0 +1,264 @@
+package horologium
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BenchmarkBasis determines what the values of a benchmark refer to.
+type BenchmarkBasis string
+
+const (
+	// PerHousehold benchmarks contain the yearly consumption of households with a certain number of occupants.
+	PerHousehold BenchmarkBasis = "occupants"
+	// PerArea benchmarks contain the yearly consumption per m² of living area, e.g. for heating.
+	PerArea BenchmarkBasis = "area"
+)
+
+// BenchmarkRow contains the reference values of a benchmark for one household size.
+type BenchmarkRow struct {
+	Occupants int       // the household size the row applies to (larger households use the last row); 0 for PerArea benchmarks
+	Values    []float64 // the yearly consumption at each percentile of the benchmark, ascending
+}
+
+// Benchmark is a table of reference consumptions, e.g. the typical power consumption by household size.
+// The values of every row are the consumptions at the percentiles of the benchmark; they divide the consumptions
+// into efficiency bands, the first band being the most efficient one.
+type Benchmark struct {
+	Name        string
+	Unit        Unit           // the unit of the values, may be unknown
+	Basis       BenchmarkBasis // what the values refer to
+	Percentiles []float64      // the percentiles of the values, ascending, e.g. 10, 25, 50, 75, 90
+	Bands       []string       // the names of the bands, one more than percentiles; A, B, C, … if empty
+	Rows        []BenchmarkRow // the rows sorted by Occupants; PerArea benchmarks have exactly one row
+}
+
+// validate checks the consistency of the benchmark, sorts its rows, and names the bands if they are not named.
+func (b *Benchmark) validate() error {
+	if b.Basis != PerHousehold && b.Basis != PerArea {
+		return fmt.Errorf("unknown basis \"%s\", expected occupants or area", b.Basis)
+	}
+	if len(b.Percentiles) == 0 {
+		return fmt.Errorf("no percentiles given")
+	}
+	for index, percentile := range b.Percentiles {
+		if percentile <= 0 || percentile >= 100 || (index > 0 && percentile <= b.Percentiles[index-1]) {
+			return fmt.Errorf("percentiles must be ascending and between 0 and 100")
+		}
+	}
+	if len(b.Bands) == 0 {
+		for index := 0; index <= len(b.Percentiles); index++ {
+			b.Bands = append(b.Bands, string(rune('A'+index)))
+		}
+	}
+	if len(b.Bands) != len(b.Percentiles)+1 {
+		return fmt.Errorf("expected %d bands, got %d", len(b.Percentiles)+1, len(b.Bands))
+	}
+	if len(b.Rows) == 0 || (b.Basis == PerArea && len(b.Rows) != 1) {
+		return fmt.Errorf("expected one row per household size, or exactly one row for area benchmarks")
+	}
+	for index, row := range b.Rows {
+		if len(row.Values) != len(b.Percentiles) {
+			return fmt.Errorf("row %d: expected %d values, got %d", index, len(b.Percentiles), len(row.Values))
+		}
+		for value := 1; value < len(row.Values); value++ {
+			if row.Values[value] < row.Values[value-1] {
+				return fmt.Errorf("row %d: values must be ascending", index)
+			}
+		}
+	}
+	sort.SliceStable(b.Rows, func(i, j int) bool {
+		return b.Rows[i].Occupants < b.Rows[j].Occupants
+	})
+	return nil
+}
+
+// row returns the row for a household with the given (possibly time-weighted) number of occupants:
+// the row of the largest household size not larger than the rounded occupants, or the first row.
+func (b *Benchmark) row(occupants float64) BenchmarkRow {
+	result := b.Rows[0]
+	for _, row := range b.Rows {
+		if float64(row.Occupants) <= math.Round(occupants) {
+			result = row
+		}
+	}
+	return result
+}
+
+// classify returns the band and the percentile of the value within the row. Between two values of the row,
+// the percentile is interpolated linearly; below the first value, it is interpolated from zero. Above the last
+// value, the last percentile is returned.
+func (b *Benchmark) classify(row BenchmarkRow, value float64) (string, float64) {
+	band := 0
+	for band < len(row.Values) && value > row.Values[band] {
+		band = band + 1
+	}
+	lowerValue, lowerPercentile := 0.0, 0.0
+	if band > 0 {
+		lowerValue, lowerPercentile = row.Values[band-1], b.Percentiles[band-1]
+	}
+	if band == len(row.Values) {
+		return b.Bands[band], lowerPercentile
+	}
+	percentile := b.Percentiles[band]
+	if row.Values[band] > lowerValue {
+		percentile = lowerPercentile + (b.Percentiles[band]-lowerPercentile)*(value-lowerValue)/(row.Values[band]-lowerValue)
+	}
+	return b.Bands[band], percentile
+}
+
+// LoadBenchmarkFromCSV reads a benchmark from a CSV file. The header consists of the basis (occupants or area)
+// followed by the percentiles; every further line contains the household size (empty or 0 for area benchmarks)
+// followed by the values, e.g.:
+//
+//	occupants,10,25,50,75,90
+//	1,1000,1400,1800,2300,2900
+//
+// The bands are named A, B, C, … and the unit is unknown.
+func LoadBenchmarkFromCSV(reader io.Reader, name string) (*Benchmark, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	csvReader.Comment = '#'
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not read benchmark: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("could not read benchmark: the file is empty")
+	}
+	result := &Benchmark{Name: name, Basis: BenchmarkBasis(strings.TrimSpace(records[0][0]))}
+	for index, field := range records[0][1:] {
+		percentile, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse percentile %d: %v", index, err)
+		}
+		result.Percentiles = append(result.Percentiles, percentile)
+	}
+	for index, record := range records[1:] {
+		row := BenchmarkRow{}
+		if occupants := strings.TrimSpace(record[0]); occupants != "" {
+			row.Occupants, err = strconv.Atoi(occupants)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse row %d: could not parse occupants: %v", index, err)
+			}
+		}
+		for _, field := range record[1:] {
+			value, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse row %d: could not parse value: %v", index, err)
+			}
+			row.Values = append(row.Values, value)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	if err := result.validate(); err != nil {
+		return nil, fmt.Errorf("invalid benchmark: %v", err)
+	}
+	return result, nil
+}
+
+// BenchmarkResult classifies the consumption of a series over a year with a benchmark.
+type BenchmarkResult struct {
+	Benchmark         Benchmark
+	From              time.Time    // the start of the year
+	To                time.Time    // the end of the year (exclusive)
+	Consumption       float64      // the consumption of the year in the unit of the benchmark
+	Occupants         float64      // the average number of occupants of the year (see Households.Between)
+	Area              float64      // the average living area of the year
+	Value             float64      // the value compared with the benchmark: the consumption, or the consumption per m²
+	Row               BenchmarkRow // the row of the benchmark the value is compared with
+	Band              string       // the efficiency band of the value
+	Percentile        float64      // the share of households in percent that consume less, interpolated between the percentiles of the benchmark
+	ConsumptionFormat string
+}
+
+// Benchmark classifies the consumption of the twelve months before the date. If the meter readings cover less
+// than these twelve months, or if excluded events (see Event) fall into them, the consumption is extrapolated to
+// the whole year. PerHousehold benchmarks need the number of occupants and PerArea benchmarks the living area of the
+// households of the series. If both the benchmark and the series have a unit, the consumption is converted
+// into the unit of the benchmark.
+func (s *Series) Benchmark(benchmark Benchmark, date time.Time) (*BenchmarkResult, error) {
+	start := date.AddDate(-1, 0, 0)
+	sorted := append(MeterReadings{}, s.MeterReadings...)
+	sorted.Sort()
+	if len(sorted) < 2 {
+		return nil, fmt.Errorf("at least two meter readings are needed")
+	}
+	coveredFrom, coveredTo := sorted[0].Date, sorted[len(sorted)-1].Date
+	if coveredFrom.Before(start) {
+		coveredFrom = start
+	}
+	if coveredTo.After(date) {
+		coveredTo = date
+	}
+	if !coveredFrom.Before(coveredTo) {
+		return nil, fmt.Errorf("no meter readings in the twelve months before %s", date.Format(DateFormat))
+	}
+	consumption, hours := s.typicalConsumption(coveredFrom, coveredTo)
+	if hours == 0 {
+		return nil, fmt.Errorf("all meter readings in the twelve months before %s are excluded by events", date.Format(DateFormat))
+	}
+	consumption = consumption * date.Sub(start).Hours() / hours
+	if benchmark.Unit.Known() && s.Unit.Known() {
+		converted, err := s.Unit.Convert(consumption, benchmark.Unit)
+		if err != nil {
+			return nil, fmt.Errorf("could not compare with %s: %v", benchmark.Name, err)
+		}
+		consumption = converted
+	}
+	occupants, area := s.Households.Between(start, date)
+	result := &BenchmarkResult{Benchmark: benchmark, From: start, To: date, Consumption: consumption, Occupants: occupants, Area: area,
+		Value: consumption, ConsumptionFormat: s.ConsumptionFormat}
+	if benchmark.Basis == PerArea {
+		if area == 0 {
+			return nil, fmt.Errorf("the living area is unknown")
+		}
+		result.Value = consumption / area
+	} else if occupants == 0 {
+		return nil, fmt.Errorf("the number of occupants is unknown")
+	}
+	result.Row = benchmark.row(occupants)
+	result.Band, result.Percentile = benchmark.classify(result.Row, result.Value)
+	return result, nil
+}
+
+// Render writes a table with the bands of the benchmark, marking the band of the value,
+// followed by a summary with the consumption and its percentile.
+func (b *BenchmarkResult) Render(writer io.Writer) {
+	format := func(value float64) string {
+		stat := Statistics{Consumption: value, ConsumptionFormat: b.ConsumptionFormat}
+		return stat.FormatConsumption()
+	}
+	columns := []tableColumn{{header: "BAND", left: true}, {header: "PERCENTILE"}, {header: "UP TO"}, {header: "", left: true}}
+	rows := make([][]string, 0, len(b.Benchmark.Bands))
+	for index, band := range b.Benchmark.Bands {
+		row := []string{band, "", "", ""}
+		if index < len(b.Row.Values) {
+			row[1] = formatNumber(b.Benchmark.Percentiles[index])
+			row[2] = format(b.Row.Values[index])
+		}
+		if band == b.Band {
+			row[3] = "◀ " + format(b.Value)
+		}
+		rows = append(rows, row)
+	}
+	renderTable(writer, columns, rows, nil)
+	basis := fmt.Sprintf("%.1f occupant(s)", b.Occupants)
+	if b.Benchmark.Basis == PerArea {
+		basis = fmt.Sprintf("%s per m² for %.1f m²", format(b.Value), b.Area)
+	} else if b.Row.Occupants > 0 {
+		basis = basis + fmt.Sprintf(", compared with households of %d", b.Row.Occupants)
+	}
+	_, _ = fmt.Fprintf(writer, "Consumption from %s to %s: %s (%s)\n", b.From.Format(DateFormat), b.To.Format(DateFormat), format(b.Consumption), basis)
+	share := fmt.Sprintf("about %.0f %%", b.Percentile)
+	if b.Value > b.Row.Values[len(b.Row.Values)-1] {
+		share = "more than " + formatNumber(b.Percentile) + " %"
+	}
+	_, _ = fmt.Fprintf(writer, "Band %s: %s of the households of %s consume less\n", b.Band, share, b.Benchmark.Name)
+}
@@ -0,0 +1,121 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+const testBenchmarkFile = `name: "Power 2023"
+unit: kWh
+basis: occupants
+percentiles: [10, 25, 50, 75, 90]
+bands: [A, B, C, D, E, F]
+rows:
+  - {occupants: 2, values: [1500, 2000, 2500, 3000, 3500]}
+  - {occupants: 1, values: [1000, 1300, 1600, 2000, 2400]}
+`
+
+func testBenchmark(t *testing.T) *Benchmark {
+	benchmark, err := LoadBenchmarkFromReader(strings.NewReader(testBenchmarkFile))
+	require.NoError(t, err, "loading the benchmark failed")
+	return benchmark
+}
+
+func TestLoadBenchmarkFromReader(t *testing.T) {
+	benchmark := testBenchmark(t)
+	assert.Equal(t, "Power 2023", benchmark.Name, "name is wrong")
+	assert.Equal(t, "kWh", benchmark.Unit.Symbol, "unit is wrong")
+	assert.Equal(t, PerHousehold, benchmark.Basis, "basis is wrong")
+	require.Equal(t, 2, len(benchmark.Rows), "number of rows is wrong")
+	assert.Equal(t, 1, benchmark.Rows[0].Occupants, "rows should be sorted by occupants")
+
+	_, err := LoadBenchmarkFromReader(strings.NewReader("basis: rooms"))
+	assert.EqualError(t, err, "invalid benchmark: unknown basis \"rooms\", expected occupants or area", "error message wrong")
+	_, err = LoadBenchmarkFromReader(strings.NewReader("basis: area\npercentiles: [50]\nbands: [A]\nrows:\n  - {values: [100]}"))
+	assert.EqualError(t, err, "invalid benchmark: expected 2 bands, got 1", "error message wrong")
+	_, err = LoadBenchmarkFromReader(strings.NewReader("basis: area\npercentiles: [50, 20]\nrows:\n  - {values: [100, 200]}"))
+	assert.EqualError(t, err, "invalid benchmark: percentiles must be ascending and between 0 and 100", "error message wrong")
+	_, err = LoadBenchmarkFromReader(strings.NewReader("basis: area\npercentiles: [20, 50]\nrows:\n  - {values: [200, 100]}"))
+	assert.EqualError(t, err, "invalid benchmark: row 0: values must be ascending", "error message wrong")
+}
+
+func TestLoadBenchmarkFromCSV(t *testing.T) {
+	got, err := LoadBenchmarkFromCSV(strings.NewReader("# heating\narea,25,50,75\n,80,120,160\n"), "Heating")
+	require.NoError(t, err, "loading the benchmark failed")
+	want := &Benchmark{Name: "Heating", Basis: PerArea, Percentiles: []float64{25, 50, 75}, Bands: []string{"A", "B", "C", "D"},
+		Rows: []BenchmarkRow{{Values: []float64{80, 120, 160}}}}
+	assert.Equal(t, want, got, "benchmark is wrong")
+
+	_, err = LoadBenchmarkFromCSV(strings.NewReader("occupants,25,50\n1,x,2"), "Power")
+	assert.EqualError(t, err, "could not parse row 0: could not parse value: strconv.ParseFloat: parsing \"x\": invalid syntax", "error message wrong")
+}
+
+func TestBenchmark_classify(t *testing.T) {
+	benchmark := testBenchmark(t)
+	row := benchmark.Rows[1]
+	tests := []struct {
+		value          float64
+		wantBand       string
+		wantPercentile float64
+	}{
+		{value: 750, wantBand: "A", wantPercentile: 5},
+		{value: 1500, wantBand: "A", wantPercentile: 10},
+		{value: 2250, wantBand: "C", wantPercentile: 37.5},
+		{value: 3500, wantBand: "E", wantPercentile: 90},
+		{value: 4000, wantBand: "F", wantPercentile: 90},
+	}
+	for _, tt := range tests {
+		band, percentile := benchmark.classify(row, tt.value)
+		assert.Equal(t, tt.wantBand, band, "band of %v is wrong", tt.value)
+		assert.InDelta(t, tt.wantPercentile, percentile, 1e-9, "percentile of %v is wrong", tt.value)
+	}
+	assert.Equal(t, 1, benchmark.row(0.6).Occupants, "small households should use the first row")
+	assert.Equal(t, 2, benchmark.row(4).Occupants, "large households should use the last row")
+}
+
+func TestSeries_Benchmark(t *testing.T) {
+	benchmark := testBenchmark(t)
+	series := Series{Unit: units["MWh"], MeterReadings: MeterReadings{
+		{Date: CreateDate(2022, 1, 1), Count: 0},
+		{Date: CreateDate(2022, 7, 2), Count: 1.1},
+	}}
+	_, err := series.Benchmark(*benchmark, CreateDate(2023, 1, 1))
+	assert.EqualError(t, err, "the number of occupants is unknown", "error message wrong")
+
+	series.Households = Households{{Occupants: 2, ValidFrom: CreateDate(2020, 1, 1)}}
+	got, err := series.Benchmark(*benchmark, CreateDate(2023, 1, 1))
+	require.NoError(t, err, "benchmark failed")
+	// half a year of readings is extrapolated to a full year and converted into kWh
+	consumption := 1100.0 * 365 / 182
+	assert.InDelta(t, consumption, got.Consumption, 1e-9, "consumption is wrong")
+	assert.Equal(t, 2, got.Row.Occupants, "row is wrong")
+	assert.Equal(t, "C", got.Band, "band is wrong")
+	assert.InDelta(t, 25+25*(consumption-2000)/500, got.Percentile, 1e-9, "percentile is wrong")
+
+	series.Unit = units["m³"]
+	_, err = series.Benchmark(*benchmark, CreateDate(2023, 1, 1))
+	assert.EqualError(t, err, "could not compare with Power 2023: cannot convert m³ (volume) to kWh (energy)", "error message wrong")
+}
+
+func ExampleBenchmarkResult_Render() {
+	benchmark, _ := LoadBenchmarkFromCSV(strings.NewReader("area,25,50,75\n,80,120,160\n"), "Heating")
+	series := Series{
+		MeterReadings: MeterReadings{{Date: CreateDate(2022, 1, 1), Count: 0}, {Date: CreateDate(2023, 1, 1), Count: 9000}},
+		Households:    Households{{Area: 90, ValidFrom: CreateDate(2020, 1, 1)}},
+	}
+	result, _ := series.Benchmark(*benchmark, CreateDate(2023, 1, 1))
+	result.Render(os.Stdout)
+	// Output:
+	// | BAND | PERCENTILE | UP TO  |          |
+	// |------|------------|--------|----------|
+	// | A    |         25 |  80.00 |          |
+	// | B    |         50 | 120.00 | ◀ 100.00 |
+	// | C    |         75 | 160.00 |          |
+	// | D    |            |        |          |
+	// |------|------------|--------|----------|
+	// Consumption from 2022-01-01 to 2023-01-01: 9000.00 (100.00 per m² for 90.0 m²)
+	// Band B: about 38 % of the households of Heating consume less
+}
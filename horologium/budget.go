@@ -0,0 +1,133 @@
+package horologium
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Budget is the amount of money a household intends to spend per month on a series.
+type Budget struct {
+	Amount    Money      // the budget of every month
+	Monthly   []Money    // optional budgets per calendar month (January first), overriding the Amount
+	ValidFrom time.Time  // the date from which the budget is used
+	ValidTo   *time.Time // the end of the budget (exclusive); if nil, the budget is valid until the next budget starts
+}
+
+// For returns the budget of a whole month.
+func (b *Budget) For(month time.Month) Money {
+	if len(b.Monthly) == 12 {
+		return b.Monthly[month-1]
+	}
+	return b.Amount
+}
+
+// Budgets is a slice of budgets.
+type Budgets []Budget
+
+// Sort sorts the budgets in ascending order by their ValidFrom date.
+func (b Budgets) Sort() {
+	sort.SliceStable(b, func(i, j int) bool {
+		return b[i].ValidFrom.Before(b[j].ValidFrom)
+	})
+}
+
+// validAt returns the budget with the latest ValidFrom on or before the date, or nil if there is none
+// or if it has already ended.
+func (b Budgets) validAt(date time.Time) *Budget {
+	var result *Budget
+	for index := range b {
+		if !b[index].ValidFrom.After(date) && (result == nil || !b[index].ValidFrom.Before(result.ValidFrom)) {
+			result = &b[index]
+		}
+	}
+	if result == nil || (result.ValidTo != nil && !date.Before(*result.ValidTo)) {
+		return nil
+	}
+	return result
+}
+
+// Between returns the budget for the time span between start and end, which must be within one month.
+// If the span covers only a part of the month, the budget of the month is reduced proportionally.
+func (b Budgets) Between(start time.Time, end time.Time) Money {
+	budget := b.validAt(start)
+	if budget == nil || !start.Before(end) {
+		return 0
+	}
+	monthStart := CreateDate(start.Year(), int(start.Month()), 1)
+	monthEnd := firstOfNextMonth(monthStart)
+	amount := budget.For(start.Month())
+	if start.Equal(monthStart) && end.Equal(monthEnd) {
+		return amount
+	}
+	return amount.Multiply(end.Sub(start).Hours() / monthEnd.Sub(monthStart).Hours())
+}
+
+// BudgetForecast compares the budget of a month with the costs expected for the whole month.
+type BudgetForecast struct {
+	Month     time.Time // the first day of the month
+	Budget    Money     // the budget of the whole month
+	Costs     Money     // the costs from the start of the month until the date of the forecast
+	Projected Money     // the costs expected for the whole month
+}
+
+// Exceeded returns whether the projected costs exceed the budget.
+func (b *BudgetForecast) Exceeded() bool {
+	return b.Projected > b.Budget
+}
+
+// ForecastBudget projects the costs of the month containing the date from the costs so far: the consumption is
+// assumed to continue at the same daily rate, and the remaining consumption is priced with the unit price of the plan
+// valid at the date (the base price of the month is already part of the costs so far).
+//
+// The consumption so far is only known up to the last meter reading, so the rest of the month is projected from there.
+// The daily rate is the rate of the month up to the last reading or, if there is no reading in the month yet,
+// the rate between the last two readings. The daily rate leaves out the periods of excluded events (see Event)
+// unless the whole time span is excluded. Returns nil if the month has no budget.
+func (s *Series) ForecastBudget(date time.Time) *BudgetForecast {
+	month := CreateDate(date.Year(), int(date.Month()), 1)
+	budget := s.Budgets.validAt(month)
+	if budget == nil {
+		return nil
+	}
+	costs, _ := s.CostsAndConsumption(month, date)
+	result := &BudgetForecast{Month: month, Budget: budget.For(month.Month()), Costs: costs, Projected: costs}
+	plan := s.PricingPlans.validAt(date)
+	sorted := append(MeterReadings{}, s.MeterReadings...)
+	sorted.Sort()
+	if plan == nil || len(sorted) < 2 {
+		return result
+	}
+	covered := date
+	if last := sorted[len(sorted)-1].Date; last.Before(covered) {
+		covered = last
+	}
+	from, projectFrom := month, covered
+	if !covered.After(month) {
+		from, projectFrom = sorted[len(sorted)-2].Date, month
+	}
+	rate, elapsed := s.typicalConsumption(from, covered)
+	if elapsed == 0 {
+		rate, elapsed = s.Consumption(from, covered), covered.Sub(from).Hours()
+	}
+	if elapsed > 0 {
+		remaining := rate * (firstOfNextMonth(month).Sub(projectFrom).Hours() / elapsed)
+		result.Projected = s.Rounding.round(RoundFinal, costs+plan.UnitPrice.Multiply(remaining))
+	}
+	return result
+}
+
+// Render writes a one-line summary of the forecast, formatting amounts with the currency format.
+func (b *BudgetForecast) Render(writer io.Writer, currencyFormat string) {
+	format := func(value Money) string {
+		stat := Statistics{Costs: value, CurrencyFormat: currencyFormat}
+		return stat.FormatCosts()
+	}
+	status := "within budget"
+	if b.Exceeded() {
+		status = "exceeds the budget by " + format(b.Projected-b.Budget)
+	}
+	_, _ = fmt.Fprintf(writer, "%s %d: costs so far %s, projected %s, budget %s (%s)\n",
+		b.Month.Month(), b.Month.Year(), format(b.Costs), format(b.Projected), format(b.Budget), status)
+}
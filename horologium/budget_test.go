@@ -0,0 +1,86 @@
+package horologium
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testBudgetSeries() *Series {
+	validTo := CreateDate(2021, 3, 1)
+	return &Series{
+		PricingPlans:  PricingPlans{{BasePrice: NewMoney(10), UnitPrice: NewMoney(0.1)}},
+		MeterReadings: MeterReadings{{Date: CreateDate(2021, 1, 1), Count: 0}, {Date: CreateDate(2021, 3, 1), Count: 590}},
+		Budgets: Budgets{
+			{Amount: NewMoney(40), ValidFrom: CreateDate(2020, 1, 1)},
+			{Monthly: []Money{NewMoney(45), NewMoney(35), 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, ValidFrom: CreateDate(2021, 1, 1), ValidTo: &validTo},
+		},
+	}
+}
+
+func TestBudgets_Between(t *testing.T) {
+	budgets := testBudgetSeries().Budgets
+	assert.Equal(t, NewMoney(40), budgets.Between(CreateDate(2020, 12, 1), CreateDate(2021, 1, 1)), "budget of a whole month is wrong")
+	assert.Equal(t, NewMoney(45), budgets.Between(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1)), "monthly budget is wrong")
+	assert.Equal(t, NewMoney(17.5), budgets.Between(CreateDate(2021, 2, 1), CreateDate(2021, 2, 15)), "budget of a partial month should be reduced")
+	assert.Equal(t, Money(0), budgets.Between(CreateDate(2021, 3, 1), CreateDate(2021, 4, 1)), "there should be no budget after validTo")
+	assert.Equal(t, Money(0), budgets.Between(CreateDate(2019, 3, 1), CreateDate(2019, 4, 1)), "there should be no budget before the first budget")
+}
+
+func ExampleMonthlyStatistics_RenderTable_budget() {
+	testBudgetSeries().MonthlyStatistics(CreateDate(2021, 1, 1), CreateDate(2021, 3, 1)).RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR | CONSUMPTION | COSTS | BUDGET | VARIANCE |
+	// |-----------|------|-------------|-------|--------|----------|
+	// | January   | 2021 |      310.00 | 41.00 |  45.00 |     4.00 |
+	// | February  |      |      280.00 | 38.00 |  35.00 |    -3.00 |
+	// |-----------|------|-------------|-------|--------|----------|
+	// | TOTAL     |      |      590.00 | 79.00 |  80.00 |     1.00 |
+	// |-----------|------|-------------|-------|--------|----------|
+}
+
+func TestSeries_ForecastBudget(t *testing.T) {
+	series := testBudgetSeries()
+	got := series.ForecastBudget(CreateDate(2021, 2, 15))
+	require.NotNil(t, got, "there should be a forecast")
+	costs, _ := series.CostsAndConsumption(CreateDate(2021, 2, 1), CreateDate(2021, 2, 15))
+	assert.Equal(t, costs, got.Costs, "costs so far are wrong")
+	assert.Equal(t, NewMoney(38), got.Projected, "consumption should be extrapolated to the whole month")
+	assert.True(t, got.Exceeded(), "the budget should be exceeded")
+	buf := new(bytes.Buffer)
+	got.Render(buf, "%.2f €")
+	assert.Equal(t, "February 2021: costs so far 24.00 €, projected 38.00 €, budget 35.00 € (exceeds the budget by 3.00 €)\n", buf.String(), "summary is wrong")
+	assert.Nil(t, series.ForecastBudget(CreateDate(2021, 3, 15)), "there should be no forecast without budget")
+}
+
+func TestSeries_ForecastBudget_LastReadingBeforeMonth(t *testing.T) {
+	series := &Series{
+		PricingPlans:  PricingPlans{{BasePrice: NewMoney(10), UnitPrice: NewMoney(1)}},
+		MeterReadings: MeterReadings{{Date: CreateDate(2021, 1, 1), Count: 0}, {Date: CreateDate(2021, 3, 1), Count: 600}},
+		Budgets:       Budgets{{Amount: NewMoney(50), ValidFrom: CreateDate(2021, 1, 1)}},
+	}
+	got := series.ForecastBudget(CreateDate(2021, 3, 20))
+	require.NotNil(t, got, "there should be a forecast")
+	assert.Equal(t, NewMoney(10), got.Costs, "only the base price is known so far")
+	// 600 units in 59 days, projected over the 31 days of March
+	assert.Equal(t, NewMoney(10+600.0/59*31).Round(2, RoundHalfUp), got.Projected.Round(2, RoundHalfUp), "consumption should be projected from the last reading interval")
+	assert.True(t, got.Exceeded(), "the budget should be exceeded")
+
+	series.MeterReadings = append(series.MeterReadings, MeterReading{Date: CreateDate(2021, 3, 11), Count: 700})
+	got = series.ForecastBudget(CreateDate(2021, 3, 20))
+	// 100 units in the first 10 days of March, projected over the remaining 21 days from the last reading
+	assert.Equal(t, NewMoney(10+100+210).Round(2, RoundHalfUp), got.Projected.Round(2, RoundHalfUp), "consumption should be projected from the last reading")
+}
+
+func TestLoadFromReader_Budgets(t *testing.T) {
+	_, err := LoadFromReader(strings.NewReader("budgets:\n  - {monthly: [1, 2], validFrom: 2021-01-01}"))
+	assert.EqualError(t, err, "could not parse budget 0: expected 12 monthly budgets, got 2", "error message wrong")
+	_, err = LoadFromReader(strings.NewReader("budgets:\n  - {amount: 80, monthly: [1, 2], validFrom: 2021-01-01}"))
+	assert.EqualError(t, err, "could not parse budget 0: either an amount or monthly budgets can be given", "error message wrong")
+	got, err := LoadFromReader(strings.NewReader("budgets:\n  - {amount: 80.5, validFrom: 2021-01-01}"))
+	require.NoError(t, err, "loading failed")
+	assert.Equal(t, Budgets{{Amount: NewMoney(80.5), ValidFrom: CreateDate(2021, 1, 1)}}, got.Budgets, "budgets are wrong")
+}
@@ -0,0 +1,162 @@
+package horologium
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Term is a length of time in months and days as used in contracts, e.g. a minimum term of 24 months
+// or a notice period of six weeks.
+type Term struct {
+	Months int
+	Days   int
+}
+
+var termPattern = regexp.MustCompile(`^((\d+)y)?((\d+)m)?((\d+)w)?((\d+)d)?$`)
+
+// ParseTerm parses a term consisting of numbers followed by a unit: y (years), m (months), w (weeks),
+// or d (days), e.g. 6w or 1y6m.
+func ParseTerm(text string) (Term, error) {
+	match := termPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil || strings.TrimSpace(text) == "" {
+		return Term{}, fmt.Errorf("invalid term \"%s\", expected numbers followed by y, m, w, or d, e.g. 6w", text)
+	}
+	number := func(index int) int {
+		result, _ := strconv.Atoi(match[index])
+		return result
+	}
+	return Term{Months: 12*number(2) + number(4), Days: 7*number(6) + number(8)}, nil
+}
+
+// String returns the term in the format accepted by ParseTerm.
+func (t Term) String() string {
+	result := ""
+	if t.Months != 0 {
+		result = fmt.Sprintf("%dm", t.Months)
+	}
+	if t.Days%7 == 0 && t.Days != 0 {
+		result = result + fmt.Sprintf("%dw", t.Days/7)
+	} else if t.Days != 0 || result == "" {
+		result = result + fmt.Sprintf("%dd", t.Days)
+	}
+	return result
+}
+
+// IsZero returns whether the term has no length.
+func (t Term) IsZero() bool {
+	return t.Months == 0 && t.Days == 0
+}
+
+// after returns the date the term after the given date.
+func (t Term) after(date time.Time) time.Time {
+	return date.AddDate(0, t.Months, t.Days)
+}
+
+// before returns the date the term before the given date.
+func (t Term) before(date time.Time) time.Time {
+	return date.AddDate(0, -t.Months, -t.Days)
+}
+
+// Contract describes the supply contract of a series.
+type Contract struct {
+	Provider          string
+	CustomerNumber    string
+	MeterNumber       string
+	ValidFrom         time.Time  // the start of the contract
+	MinimumTerm       Term       // the minimum term of the contract
+	NoticePeriod      Term       // the time before the end of a term by which the contract must be cancelled
+	Renewal           Term       // the length by which the contract is renewed automatically; zero if it can be cancelled at any time after the minimum term
+	PriceGuaranteeEnd *time.Time // the end of the price guarantee (exclusive), nil if there is none
+}
+
+// Deadline returns the latest date on which the contract can be cancelled when cancelling on or after the given date,
+// as well as the date on which the contract then ends. If the contract is not renewed for fixed terms and the minimum
+// term is over (or cannot be met anymore), it can be cancelled at any time: the deadline is the given date.
+func (c *Contract) Deadline(date time.Time) (time.Time, time.Time) {
+	end := c.MinimumTerm.after(c.ValidFrom)
+	for c.NoticePeriod.before(end).Before(date) {
+		if c.Renewal.IsZero() {
+			return date, c.NoticePeriod.after(date)
+		}
+		end = c.Renewal.after(end)
+	}
+	return c.NoticePeriod.before(end), end
+}
+
+// Contracts is a slice of contracts.
+type Contracts []Contract
+
+// Sort sorts the contracts in ascending order by their ValidFrom date.
+func (c Contracts) Sort() {
+	sort.SliceStable(c, func(i, j int) bool {
+		return c[i].ValidFrom.Before(c[j].ValidFrom)
+	})
+}
+
+// validAt returns the contract with the latest ValidFrom on or before the date, or nil if there is none.
+func (c Contracts) validAt(date time.Time) *Contract {
+	var result *Contract
+	for index := range c {
+		if !c[index].ValidFrom.After(date) && (result == nil || !c[index].ValidFrom.Before(result.ValidFrom)) {
+			result = &c[index]
+		}
+	}
+	return result
+}
+
+// ContractStatus is the cancellation status of the current contract of a series at a certain date.
+type ContractStatus struct {
+	Name     string    // the name of the series
+	Contract Contract  // the contract valid at the date
+	Date     time.Time // the date of the status
+	Deadline time.Time // the latest date to cancel the contract (see Contract.Deadline)
+	End      time.Time // the end of the contract if cancelled by the deadline
+}
+
+// DaysLeft returns the number of days from the date of the status until the deadline.
+func (c *ContractStatus) DaysLeft() int {
+	return int(math.Round(c.Deadline.Sub(c.Date).Hours() / 24))
+}
+
+// ContractStatuses is a slice of contract statuses.
+type ContractStatuses []ContractStatus
+
+// Contracts returns the status of the contract valid at the date for every series with contracts,
+// sorted by the cancellation deadline.
+func (p Portfolio) Contracts(date time.Time) ContractStatuses {
+	result := make(ContractStatuses, 0, len(p))
+	for _, series := range p {
+		contract := series.Contracts.validAt(date)
+		if contract == nil {
+			continue
+		}
+		deadline, end := contract.Deadline(date)
+		result = append(result, ContractStatus{Name: series.Name, Contract: *contract, Date: date, Deadline: deadline, End: end})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Deadline.Before(result[j].Deadline)
+	})
+	return result
+}
+
+// RenderTable renders the contract statuses as table.
+func (c ContractStatuses) RenderTable(writer io.Writer) {
+	columns := []tableColumn{{header: "SERIES", left: true}, {header: "PROVIDER", left: true}, {header: "CUSTOMER NO.", left: true},
+		{header: "METER NO.", left: true}, {header: "PRICE GUARANTEE", left: true}, {header: "CANCEL BY", left: true}, {header: "ENDS", left: true}, {header: "DAYS LEFT"}}
+	rows := make([][]string, 0, len(c))
+	for _, status := range c {
+		guarantee := "-"
+		if status.Contract.PriceGuaranteeEnd != nil {
+			guarantee = status.Contract.PriceGuaranteeEnd.Format(DateFormat)
+		}
+		rows = append(rows, []string{status.Name, status.Contract.Provider, status.Contract.CustomerNumber, status.Contract.MeterNumber,
+			guarantee, status.Deadline.Format(DateFormat), status.End.Format(DateFormat), strconv.Itoa(status.DaysLeft())})
+	}
+	renderTable(writer, columns, rows, nil)
+}
@@ -0,0 +1,86 @@
+package horologium
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseTerm(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   Term
+		errMsg string
+	}{
+		{input: "6w", want: Term{Days: 42}},
+		{input: "1y6m", want: Term{Months: 18}},
+		{input: "3m14d", want: Term{Months: 3, Days: 14}},
+		{input: "6", errMsg: "invalid term \"6\", expected numbers followed by y, m, w, or d, e.g. 6w"},
+		{input: "", errMsg: "invalid term \"\", expected numbers followed by y, m, w, or d, e.g. 6w"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTerm(tt.input)
+			if tt.errMsg != "" {
+				assert.EqualError(t, err, tt.errMsg, "error message wrong")
+				return
+			}
+			require.NoError(t, err, "parsing failed")
+			assert.Equal(t, tt.want, got, "term is wrong")
+			parsed, _ := ParseTerm(got.String())
+			assert.Equal(t, got, parsed, "the string of the term should be parsable")
+		})
+	}
+}
+
+func TestContract_Deadline(t *testing.T) {
+	contract := Contract{ValidFrom: CreateDate(2023, 1, 1), MinimumTerm: Term{Months: 12}, NoticePeriod: Term{Days: 42}, Renewal: Term{Months: 12}}
+	deadline, end := contract.Deadline(CreateDate(2023, 6, 1))
+	assert.Equal(t, CreateDate(2023, 11, 20), deadline, "deadline in the minimum term is wrong")
+	assert.Equal(t, CreateDate(2024, 1, 1), end, "end of the minimum term is wrong")
+	deadline, end = contract.Deadline(CreateDate(2023, 12, 1))
+	assert.Equal(t, CreateDate(2024, 11, 20), deadline, "after the deadline, the contract should be renewed")
+	assert.Equal(t, CreateDate(2025, 1, 1), end, "end of the renewed term is wrong")
+
+	contract.Renewal = Term{}
+	contract.NoticePeriod = Term{Months: 1}
+	deadline, end = contract.Deadline(CreateDate(2024, 3, 10))
+	assert.Equal(t, CreateDate(2024, 3, 10), deadline, "without renewal, the contract can be cancelled any time")
+	assert.Equal(t, CreateDate(2024, 4, 10), end, "the contract should end after the notice period")
+}
+
+func ExampleContractStatuses_RenderTable() {
+	guarantee := CreateDate(2024, 1, 1)
+	power := &Series{Name: "Power", Contracts: Contracts{
+		{Provider: "Old provider", ValidFrom: CreateDate(2020, 1, 1)},
+		{Provider: "Power & Co", CustomerNumber: "4711", MeterNumber: "1ESY1160123", ValidFrom: CreateDate(2023, 1, 1),
+			MinimumTerm: Term{Months: 12}, NoticePeriod: Term{Days: 42}, Renewal: Term{Months: 12}, PriceGuaranteeEnd: &guarantee},
+	}}
+	water := &Series{Name: "Water"}
+	Portfolio{power, water}.Contracts(CreateDate(2023, 6, 1)).RenderTable(os.Stdout)
+	// Output:
+	// | SERIES |  PROVIDER  | CUSTOMER NO. |  METER NO.  | PRICE GUARANTEE | CANCEL BY  |    ENDS    | DAYS LEFT |
+	// |--------|------------|--------------|-------------|-----------------|------------|------------|-----------|
+	// | Power  | Power & Co | 4711         | 1ESY1160123 | 2024-01-01      | 2023-11-20 | 2024-01-01 |       172 |
+	// |--------|------------|--------------|-------------|-----------------|------------|------------|-----------|
+}
+
+func TestPortfolio_WriteCalendar_Contracts(t *testing.T) {
+	series := &Series{Name: "Power", Contracts: Contracts{{Provider: "Power & Co", ValidFrom: CreateDate(2023, 1, 1),
+		MinimumTerm: Term{Months: 12}, NoticePeriod: Term{Days: 42}, Renewal: Term{Months: 12}}}}
+	buf := new(bytes.Buffer)
+	require.NoError(t, Portfolio{series}.WriteCalendar(buf, CreateDate(2023, 6, 1)), "writing the calendar failed")
+	assert.Contains(t, buf.String(), "DTSTART;VALUE=DATE:20231120\r\n", "the deadline should be in the calendar")
+	assert.Contains(t, buf.String(), "SUMMARY:Power: last day to cancel the contract with Power & Co\r\n", "summary is wrong")
+}
+
+func TestLoadFromReader_Contracts(t *testing.T) {
+	got, err := LoadFromReader(strings.NewReader("contracts:\n  - {provider: Power & Co, validFrom: 2023-01-01, minimumTerm: 2y, noticePeriod: 6w}"))
+	require.NoError(t, err, "loading failed")
+	assert.Equal(t, Contracts{{Provider: "Power & Co", ValidFrom: CreateDate(2023, 1, 1), MinimumTerm: Term{Months: 24}, NoticePeriod: Term{Days: 42}}}, got.Contracts, "contracts are wrong")
+	_, err = LoadFromReader(strings.NewReader("contracts:\n  - {validFrom: 2023-01-01, renewal: one year}"))
+	assert.EqualError(t, err, "could not parse contract 0: could not parse renewal: invalid term \"one year\", expected numbers followed by y, m, w, or d, e.g. 6w", "error message wrong")
+}
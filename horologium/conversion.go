@@ -0,0 +1,113 @@
+package horologium
+
+import (
+	"sort"
+	"time"
+)
+
+// ConversionFactor converts the consumption counted by the meter into the unit it is billed in.
+// For example, gas meters count cubic metres while gas is billed in kWh: one cubic metre
+// yields the calorific value (in kWh per m³) times the state number (Z-number), which corrects
+// the volume for the pressure and temperature at the meter.
+type ConversionFactor struct {
+	CalorificValue float64   // the energy per meter unit, e.g. 11.2 kWh per m³
+	StateNumber    float64   // the correction of the volume, e.g. 0.9533
+	ValidFrom      time.Time // the date from which the factor is used, usually the start of a billing period
+}
+
+// Factor returns the number of billing units per meter unit.
+func (c *ConversionFactor) Factor() float64 {
+	return c.CalorificValue * c.StateNumber
+}
+
+// ConversionFactors is a slice of conversion factors.
+type ConversionFactors []ConversionFactor
+
+// Sort sorts the conversion factors in ascending order by their ValidFrom date.
+func (c ConversionFactors) Sort() {
+	sort.SliceStable(c, func(i, j int) bool {
+		return c[i].ValidFrom.Before(c[j].ValidFrom)
+	})
+}
+
+// factorAt returns the factor used at the given date: the factor with the latest ValidFrom on or before the date.
+// Dates before the first factor use the first factor.
+func (c ConversionFactors) factorAt(date time.Time) float64 {
+	index := factorIndexAt(len(c), func(i int) time.Time { return c[i].ValidFrom }, date)
+	if index < 0 {
+		return 1
+	}
+	return c[index].Factor()
+}
+
+// factorIndexAt returns the index of the dated factor used at the given date: the factor with the latest
+// valid-from date on or before the date, or the factor with the earliest one for dates before all factors.
+// The valid-from dates are given by index; -1 is returned if there are no factors.
+func factorIndexAt(length int, validFrom func(index int) time.Time, date time.Time) int {
+	result := -1
+	for index := 0; index < length; index++ {
+		if !validFrom(index).After(date) && (result < 0 || !validFrom(index).Before(validFrom(result))) {
+			result = index
+		}
+	}
+	if result >= 0 {
+		return result
+	}
+	for index := 0; index < length; index++ {
+		if result < 0 || validFrom(index).Before(validFrom(result)) {
+			result = index
+		}
+	}
+	return result
+}
+
+// Consumption computes the consumption between start and end in the billing unit, i.e. the consumption
+// counted by the meter (see MeterReadings.InterpolatedConsumption) converted with the conversion factors of the series.
+// If the factor changes between start and end, each part is converted with its own factor.
+// Without conversion factors, the consumption is only converted if the meter unit and the unit of the series
+// measure the same dimension, e.g. from Wh into kWh (see Unit.Convert).
+func (s *Series) Consumption(start time.Time, end time.Time) float64 {
+	if len(s.ConversionFactors) == 0 {
+		consumption := s.meterConsumption(start, end)
+		if converted, err := s.MeterUnit.Convert(consumption, s.Unit); err == nil {
+			return converted
+		}
+		return consumption
+	}
+	changes := make([]time.Time, 0, len(s.ConversionFactors))
+	for _, factor := range s.ConversionFactors {
+		changes = append(changes, factor.ValidFrom)
+	}
+	boundaries := splitAt(start, end, changes)
+	result := 0.0
+	for index := 0; index+1 < len(boundaries); index++ {
+		raw := s.meterConsumption(boundaries[index], boundaries[index+1])
+		result = result + raw*s.ConversionFactors.factorAt(boundaries[index])
+	}
+	return result
+}
+
+// meterConsumption computes the consumption in the meter unit with the interpolation of the series.
+// If the series ignores estimates, estimated readings between other readings are skipped.
+func (s *Series) meterConsumption(start time.Time, end time.Time) float64 {
+	readings := s.MeterReadings
+	if s.IgnoreEstimates {
+		readings = readings.withoutEstimates()
+	}
+	return readings.InterpolatedConsumption(start, end, s.Interpolation)
+}
+
+// splitAt splits the time span between start and end at all given dates within the span. The result
+// contains the start, the sorted dates within the span, and the end; consecutive entries form the parts.
+func splitAt(start time.Time, end time.Time, dates []time.Time) []time.Time {
+	result := []time.Time{start}
+	for _, date := range dates {
+		if date.After(start) && date.Before(end) {
+			result = append(result, date)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Before(result[j])
+	})
+	return append(result, end)
+}
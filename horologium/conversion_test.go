@@ -0,0 +1,70 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testGasSeries() *Series {
+	return &Series{
+		ConsumptionFormat: "%.1f kWh",
+		MeterFormat:       "%.1f m³",
+		PricingPlans:      PricingPlans{{BasePrice: NewMoney(10), UnitPrice: NewMoney(0.1)}},
+		MeterReadings:     MeterReadings{{Date: CreateDate(2022, 12, 1), Count: 0}, {Date: CreateDate(2023, 1, 1), Count: 310}, {Date: CreateDate(2023, 2, 1), Count: 620}},
+		ConversionFactors: ConversionFactors{
+			{CalorificValue: 11, StateNumber: 0.95, ValidFrom: CreateDate(2023, 1, 16)},
+			{CalorificValue: 10, StateNumber: 1, ValidFrom: CreateDate(2022, 1, 1)},
+		},
+	}
+}
+
+func TestConversionFactors_factorAt(t *testing.T) {
+	factors := testGasSeries().ConversionFactors
+	assert.Equal(t, 10.0, factors.factorAt(CreateDate(2021, 6, 1)), "dates before the first factor should use the first factor")
+	assert.Equal(t, 10.0, factors.factorAt(CreateDate(2023, 1, 15)), "factor before the change is wrong")
+	assert.InDelta(t, 10.45, factors.factorAt(CreateDate(2023, 1, 16)), 1e-9, "factor after the change is wrong")
+	assert.Equal(t, 1.0, ConversionFactors{}.factorAt(CreateDate(2023, 1, 16)), "without factors, the meter unit is the billing unit")
+}
+
+func TestSeries_Consumption(t *testing.T) {
+	series := testGasSeries()
+	assert.Equal(t, 3100.0, series.Consumption(CreateDate(2022, 12, 1), CreateDate(2023, 1, 1)), "consumption of December is wrong")
+	assert.InDelta(t, 150*10+160*10.45, series.Consumption(CreateDate(2023, 1, 1), CreateDate(2023, 2, 1)), 1e-9, "January should be split at the factor change")
+	series.ConversionFactors = nil
+	assert.Equal(t, 310.0, series.Consumption(CreateDate(2023, 1, 1), CreateDate(2023, 2, 1)), "without factors, the meter consumption should be returned")
+}
+
+func TestSeries_CostsAndConsumption_ConversionFactors(t *testing.T) {
+	costs, consumption := testGasSeries().CostsAndConsumption(CreateDate(2022, 12, 1), CreateDate(2023, 1, 1))
+	assert.Equal(t, 3100.0, consumption, "consumption should be in the billing unit")
+	assert.Equal(t, NewMoney(320), costs, "the unit price should apply to the billing unit")
+}
+
+func ExampleMonthlyStatistics_RenderTable_conversionFactors() {
+	testGasSeries().MonthlyStatistics(CreateDate(2022, 12, 1), CreateDate(2023, 2, 1)).RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR | CONSUMPTION |  METER   | COSTS  |
+	// |-----------|------|-------------|----------|--------|
+	// | December  | 2022 |  3100.0 kWh | 310.0 m³ | 320.00 |
+	// | January   | 2023 |  3172.0 kWh | 310.0 m³ | 327.20 |
+	// |-----------|------|-------------|----------|--------|
+	// | TOTAL     |      |  6272.0 kWh | 620.0 m³ | 647.20 |
+	// |-----------|------|-------------|----------|--------|
+}
+
+func TestLoadFromReader_ConversionFactors(t *testing.T) {
+	file := `conversionFactors:
+  - {calorificValue: 11.2, stateNumber: 0.9533, validFrom: 2023-01-01}
+  - {calorificValue: 11.1, validFrom: 2022-01-01}`
+	got, err := LoadFromReader(strings.NewReader(file))
+	require.NoError(t, err, "loading failed")
+	require.Equal(t, 2, len(got.ConversionFactors), "number of conversion factors is wrong")
+	assert.Equal(t, ConversionFactor{CalorificValue: 11.2, StateNumber: 0.9533, ValidFrom: CreateDate(2023, 1, 1)}, got.ConversionFactors[0], "first factor is wrong")
+	assert.Equal(t, 1.0, got.ConversionFactors[1].StateNumber, "state number should default to 1")
+
+	_, err = LoadFromReader(strings.NewReader("conversionFactors:\n  - {calorificValue: 0, validFrom: 2023-01-01}"))
+	assert.EqualError(t, err, "could not parse conversion factor 0: calorific value must be positive, got 0", "error message wrong")
+}
@@ -0,0 +1,76 @@
+package horologium
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// EmissionFactor describes the footprint of one unit of consumption (in the billing unit of the series),
+// e.g. the CO₂ emissions and the primary-energy factor of the power mix of a provider in a certain year.
+type EmissionFactor struct {
+	Emission      float64   // the emissions in gram CO₂ (equivalents) per unit
+	PrimaryEnergy float64   // the primary energy needed per unit, e.g. 1.8 for power from the grid
+	ValidFrom     time.Time // the date from which the factor is used
+}
+
+// EmissionFactors is a slice of emission factors.
+type EmissionFactors []EmissionFactor
+
+// Sort sorts the emission factors in ascending order by their ValidFrom date.
+func (e EmissionFactors) Sort() {
+	sort.SliceStable(e, func(i, j int) bool {
+		return e[i].ValidFrom.Before(e[j].ValidFrom)
+	})
+}
+
+// factorAt returns the factor used at the given date: the factor with the latest ValidFrom on or before the date.
+// Dates before the first factor use the first factor.
+func (e EmissionFactors) factorAt(date time.Time) EmissionFactor {
+	index := factorIndexAt(len(e), func(i int) time.Time { return e[i].ValidFrom }, date)
+	if index < 0 {
+		return EmissionFactor{}
+	}
+	return e[index]
+}
+
+// Footprint computes the emissions (in kg CO₂) and the primary energy caused by the consumption between start
+// and end (see Series.Consumption). If the emission factor changes between start and end, each part is computed
+// with its own factor. Without emission factors, the footprint is zero.
+func (s *Series) Footprint(start time.Time, end time.Time) (float64, float64) {
+	if len(s.EmissionFactors) == 0 {
+		return 0, 0
+	}
+	changes := make([]time.Time, 0, len(s.EmissionFactors))
+	for _, factor := range s.EmissionFactors {
+		changes = append(changes, factor.ValidFrom)
+	}
+	boundaries := splitAt(start, end, changes)
+	emissions := 0.0
+	primaryEnergy := 0.0
+	for index := 0; index+1 < len(boundaries); index++ {
+		consumption := s.Consumption(boundaries[index], boundaries[index+1])
+		factor := s.EmissionFactors.factorAt(boundaries[index])
+		emissions = emissions + consumption*factor.Emission/1000
+		primaryEnergy = primaryEnergy + consumption*factor.PrimaryEnergy
+	}
+	return emissions, primaryEnergy
+}
+
+// RenderFootprintTable renders the consumption, emissions, and primary energy of the statistics as table
+// with one row per statistics, usually per year (see ByYear).
+func (m MonthlyStatistics) RenderFootprintTable(writer io.Writer) {
+	columns := []tableColumn{{header: "FROM", left: true}, {header: "TO", left: true}, {header: "CONSUMPTION"}, {header: "CO₂ (kg)"}, {header: "PRIMARY ENERGY"}}
+	rows := make([][]string, 0, len(m))
+	for _, stat := range m {
+		rows = append(rows, []string{
+			stat.ValidFrom.Format(DateFormat),
+			stat.ValidTo.Format(DateFormat),
+			stat.FormatConsumption(),
+			fmt.Sprintf("%.1f", stat.Emissions),
+			fmt.Sprintf("%.1f", stat.PrimaryEnergy),
+		})
+	}
+	renderTable(writer, columns, rows, nil)
+}
@@ -0,0 +1,73 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testEmissionSeries() *Series {
+	return &Series{
+		PricingPlans:  PricingPlans{{BasePrice: NewMoney(10), UnitPrice: NewMoney(0.3)}},
+		MeterReadings: MeterReadings{{Date: CreateDate(2022, 12, 1), Count: 0}, {Date: CreateDate(2023, 2, 1), Count: 620}},
+		EmissionFactors: EmissionFactors{
+			{Emission: 400, PrimaryEnergy: 1.8, ValidFrom: CreateDate(2022, 1, 1)},
+			{Emission: 300, PrimaryEnergy: 1.5, ValidFrom: CreateDate(2023, 1, 1)},
+		},
+	}
+}
+
+func TestSeries_Footprint(t *testing.T) {
+	series := testEmissionSeries()
+	emissions, primaryEnergy := series.Footprint(CreateDate(2022, 12, 1), CreateDate(2023, 2, 1))
+	assert.InDelta(t, 310*0.4+310*0.3, emissions, 1e-9, "emissions should be split at the factor change")
+	assert.InDelta(t, 310*1.8+310*1.5, primaryEnergy, 1e-9, "primary energy should be split at the factor change")
+	series.EmissionFactors = nil
+	emissions, primaryEnergy = series.Footprint(CreateDate(2022, 12, 1), CreateDate(2023, 2, 1))
+	assert.Equal(t, 0.0, emissions+primaryEnergy, "without factors, there is no footprint")
+}
+
+func ExampleMonthlyStatistics_RenderTable_emissions() {
+	testEmissionSeries().MonthlyStatistics(CreateDate(2022, 12, 1), CreateDate(2023, 2, 1)).RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR | CONSUMPTION | COSTS  | CO₂ (kg) | PRIMARY ENERGY |
+	// |-----------|------|-------------|--------|----------|----------------|
+	// | December  | 2022 |      310.00 | 103.00 |    124.0 |          558.0 |
+	// | January   | 2023 |      310.00 | 103.00 |     93.0 |          465.0 |
+	// |-----------|------|-------------|--------|----------|----------------|
+	// | TOTAL     |      |      620.00 | 206.00 |    217.0 |         1023.0 |
+	// |-----------|------|-------------|--------|----------|----------------|
+}
+
+func TestMonthlyStatistics_ByYear(t *testing.T) {
+	series := testEmissionSeries()
+	series.MeterReadings = append(series.MeterReadings, MeterReading{Date: CreateDate(2023, 3, 1), Count: 900})
+	got := series.MonthlyStatistics(CreateDate(2022, 12, 1), CreateDate(2023, 3, 1)).ByYear()
+	require.Equal(t, 2, len(got), "there should be one statistics per year")
+	assert.Equal(t, CreateDate(2023, 1, 1), got[1].ValidFrom, "start of the second year is wrong")
+	assert.Equal(t, CreateDate(2023, 3, 1), got[1].ValidTo, "end of the second year is wrong")
+	assert.Equal(t, 590.0, got[1].Consumption, "consumption of the second year is wrong")
+	assert.Equal(t, NewMoney(20+590*0.3), got[1].Costs, "costs of the second year are wrong")
+	assert.InDelta(t, 590*0.3, got[1].Emissions, 1e-9, "emissions of the second year are wrong")
+}
+
+func ExampleMonthlyStatistics_RenderFootprintTable() {
+	series := testEmissionSeries()
+	series.MonthlyStatistics(CreateDate(2022, 12, 1), CreateDate(2023, 2, 1)).ByYear().RenderFootprintTable(os.Stdout)
+	// Output:
+	// |    FROM    |     TO     | CONSUMPTION | CO₂ (kg) | PRIMARY ENERGY |
+	// |------------|------------|-------------|----------|----------------|
+	// | 2022-12-01 | 2023-01-01 |      310.00 |    124.0 |          558.0 |
+	// | 2023-01-01 | 2023-02-01 |      310.00 |     93.0 |          465.0 |
+	// |------------|------------|-------------|----------|----------------|
+}
+
+func TestLoadFromReader_EmissionFactors(t *testing.T) {
+	got, err := LoadFromReader(strings.NewReader("emissionFactors:\n  - {emission: 380, primaryEnergy: 1.8, validFrom: 2023-01-01}"))
+	require.NoError(t, err, "loading failed")
+	assert.Equal(t, EmissionFactors{{Emission: 380, PrimaryEnergy: 1.8, ValidFrom: CreateDate(2023, 1, 1)}}, got.EmissionFactors, "emission factors are wrong")
+	_, err = LoadFromReader(strings.NewReader("emissionFactors:\n  - {emission: -1, validFrom: 2023-01-01}"))
+	assert.EqualError(t, err, "could not parse emission factor 0: emission and primary energy must not be negative", "error message wrong")
+}
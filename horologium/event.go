@@ -0,0 +1,101 @@
+package horologium
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Event annotates a period that influences the consumption, e.g. a vacation, guests, or a renovation.
+type Event struct {
+	Label     string
+	ValidFrom time.Time // the first day of the event
+	ValidTo   time.Time // the end of the event (exclusive)
+	Exclude   bool      // whether the period is left out of averages, forecasts, goal baselines, and anomaly baselines
+}
+
+// Events is a slice of events.
+type Events []Event
+
+// Sort sorts the events in ascending order by their ValidFrom date.
+func (e Events) Sort() {
+	sort.SliceStable(e, func(i, j int) bool {
+		return e[i].ValidFrom.Before(e[j].ValidFrom)
+	})
+}
+
+// Between returns the events overlapping the time span between start and end.
+func (e Events) Between(start time.Time, end time.Time) Events {
+	result := make(Events, 0)
+	for _, event := range e {
+		if event.ValidFrom.Before(end) && event.ValidTo.After(start) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// contains returns whether the events contain the event.
+func (e Events) contains(event Event) bool {
+	for _, other := range e {
+		if other == event {
+			return true
+		}
+	}
+	return false
+}
+
+// labels returns the labels of the events separated by commas.
+func (e Events) labels() string {
+	labels := make([]string, 0, len(e))
+	for _, event := range e {
+		labels = append(labels, event.Label)
+	}
+	return strings.Join(labels, ", ")
+}
+
+// excludes returns whether an excluded event overlaps the time span between start and end.
+func (e Events) excludes(start time.Time, end time.Time) bool {
+	for _, event := range e.Between(start, end) {
+		if event.Exclude {
+			return true
+		}
+	}
+	return false
+}
+
+// includedSpans splits the time span between start and end into the spans not covered by excluded events.
+func (e Events) includedSpans(start time.Time, end time.Time) [][2]time.Time {
+	result := make([][2]time.Time, 0)
+	spanStart := start
+	for _, event := range e.Between(start, end) {
+		if !event.Exclude {
+			continue
+		}
+		if event.ValidFrom.After(spanStart) {
+			result = append(result, [2]time.Time{spanStart, event.ValidFrom})
+		}
+		if event.ValidTo.After(spanStart) {
+			spanStart = event.ValidTo
+		}
+	}
+	if spanStart.Before(end) {
+		result = append(result, [2]time.Time{spanStart, end})
+	}
+	return result
+}
+
+// typicalConsumption returns the consumption between start and end without the periods of excluded events,
+// together with the hours in which it was consumed. Without excluded events, it equals the Consumption over the
+// whole time span.
+func (s *Series) typicalConsumption(start time.Time, end time.Time) (float64, float64) {
+	events := append(Events{}, s.Events...)
+	events.Sort()
+	consumption := 0.0
+	hours := 0.0
+	for _, span := range events.includedSpans(start, end) {
+		consumption = consumption + s.Consumption(span[0], span[1])
+		hours = hours + span[1].Sub(span[0]).Hours()
+	}
+	return consumption, hours
+}
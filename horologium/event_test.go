@@ -0,0 +1,107 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testEventSeries() *Series {
+	// 100 units per month, but only 10 units during the vacation in March
+	series := testPaymentSeries()
+	series.MeterReadings[3].Count = 210
+	series.MeterReadings[4].Count = 310
+	series.Events = Events{
+		{Label: "Vacation", ValidFrom: CreateDate(2020, 3, 1), ValidTo: CreateDate(2020, 4, 1), Exclude: true},
+		{Label: "Guests", ValidFrom: CreateDate(2020, 1, 20), ValidTo: CreateDate(2020, 2, 5)},
+	}
+	return series
+}
+
+func TestEvents_Between(t *testing.T) {
+	events := testEventSeries().Events
+	assert.Equal(t, Events{events[1]}, events.Between(CreateDate(2020, 2, 1), CreateDate(2020, 3, 1)), "only the guests overlap February")
+	assert.Empty(t, events.Between(CreateDate(2020, 4, 1), CreateDate(2020, 5, 1)), "the vacation ends before April")
+}
+
+func TestEvents_includedSpans(t *testing.T) {
+	events := Events{
+		{ValidFrom: CreateDate(2020, 1, 5), ValidTo: CreateDate(2020, 1, 10), Exclude: true},
+		{ValidFrom: CreateDate(2020, 1, 8), ValidTo: CreateDate(2020, 1, 12), Exclude: true},
+		{ValidFrom: CreateDate(2020, 1, 15), ValidTo: CreateDate(2020, 1, 20)},
+		{ValidFrom: CreateDate(2020, 1, 25), ValidTo: CreateDate(2020, 2, 5), Exclude: true},
+	}
+	want := [][2]time.Time{{CreateDate(2020, 1, 1), CreateDate(2020, 1, 5)}, {CreateDate(2020, 1, 12), CreateDate(2020, 1, 25)}}
+	assert.Equal(t, want, events.includedSpans(CreateDate(2020, 1, 1), CreateDate(2020, 2, 1)), "spans are wrong")
+	assert.Equal(t, [][2]time.Time{{CreateDate(2020, 1, 1), CreateDate(2020, 2, 1)}}, Events{}.includedSpans(CreateDate(2020, 1, 1), CreateDate(2020, 2, 1)), "without events, the whole span is included")
+}
+
+func TestSeries_RecommendInstallment_Events(t *testing.T) {
+	series := testEventSeries()
+	got, err := series.RecommendInstallment(CreateDate(2020, 5, 1), 0)
+	require.NoError(t, err, "recommendation failed")
+	// the three months without vacation are extrapolated to a full year
+	assert.InDelta(t, 300.0*366/(31+29+30), got.Consumption, 1e-9, "consumption is wrong")
+
+	series.Events[0].Exclude = false
+	got, err = series.RecommendInstallment(CreateDate(2020, 5, 1), 0)
+	require.NoError(t, err, "recommendation failed")
+	assert.InDelta(t, 310.0*366/121, got.Consumption, 1e-9, "events that are not excluded must not change the consumption")
+
+	series.Events = Events{{Label: "Abroad", ValidFrom: CreateDate(2019, 1, 1), ValidTo: CreateDate(2021, 1, 1), Exclude: true}}
+	_, err = series.RecommendInstallment(CreateDate(2020, 5, 1), 0)
+	assert.EqualError(t, err, "all meter readings in the twelve months before 2020-05-01 are excluded by events", "error message wrong")
+}
+
+func TestSeries_Progress_Events(t *testing.T) {
+	series := testEventSeries()
+	goal := Goal{BaselineFrom: CreateDate(2020, 2, 1), BaselineTo: CreateDate(2020, 4, 1), ValidFrom: CreateDate(2020, 4, 1), ValidTo: CreateDate(2020, 5, 1)}
+	got, err := series.Progress(goal, CreateDate(2020, 5, 1))
+	require.NoError(t, err, "progress failed")
+	assert.InDelta(t, 110.0, got.Baseline, 1e-9, "the baseline should contain the whole consumption")
+	assert.InDelta(t, 100.0/29*30, got.Target, 1e-9, "the target should be based on February only")
+}
+
+func TestSeries_Anomalies_Events(t *testing.T) {
+	readings := testAnomalyReadings()
+	for index := 25; index < len(readings); index++ {
+		// a vacation in January 2022 with hardly any consumption
+		readings[index].Count = readings[index].Count - 25
+	}
+	// a small leak in January 2023
+	readings = append(readings, MeterReading{Date: CreateDate(2023, 1, 1), Count: readings[len(readings)-1].Count + 31})
+	readings = append(readings, MeterReading{Date: CreateDate(2023, 2, 1), Count: readings[len(readings)-1].Count + 45})
+	series := Series{MeterReadings: readings}
+	assert.Empty(t, series.Anomalies(DefaultAnomalyThreshold).Since(CreateDate(2022, 2, 2)), "the vacation should hide the leak")
+	series.Events = Events{{Label: "Vacation", ValidFrom: CreateDate(2022, 1, 1), ValidTo: CreateDate(2022, 2, 1), Exclude: true}}
+	got := series.Anomalies(DefaultAnomalyThreshold).Since(CreateDate(2022, 2, 2))
+	require.Equal(t, 1, len(got), "the leak should be detected without the vacation in the baseline")
+	assert.Equal(t, CreateDate(2023, 1, 1), got[0].Start, "start of the anomaly is wrong")
+}
+
+func TestLoadFromReader_Events(t *testing.T) {
+	got, err := LoadFromReader(strings.NewReader("events:\n  - {label: \"Vacation\", validFrom: 2020-03-01, validTo: 2020-04-01, exclude: true}"))
+	require.NoError(t, err, "loading failed")
+	assert.Equal(t, Events{{Label: "Vacation", ValidFrom: CreateDate(2020, 3, 1), ValidTo: CreateDate(2020, 4, 1), Exclude: true}}, got.Events, "events are wrong")
+	_, err = LoadFromReader(strings.NewReader("events:\n  - {label: \"Vacation\", validFrom: 2020-03-01, validTo: 2020-03-01}"))
+	assert.EqualError(t, err, "could not parse event 0: the event must end after it starts", "error message wrong")
+}
+
+func ExampleMonthlyStatistics_RenderTable_events() {
+	series := testEventSeries()
+	series.AdvancePayments = nil
+	series.MonthlyStatistics(CreateDate(2020, 1, 1), CreateDate(2020, 5, 1)).RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR | CONSUMPTION | COSTS  |  EVENTS  |
+	// |-----------|------|-------------|--------|----------|
+	// | January   | 2020 |      100.00 | 105.00 | Guests   |
+	// | February  |      |      100.00 | 105.00 | Guests   |
+	// | March     |      |       10.00 |  15.00 | Vacation |
+	// | April     |      |      100.00 | 105.00 |          |
+	// |-----------|------|-------------|--------|----------|
+	// | TOTAL     |      |      310.00 | 330.00 |          |
+	// |-----------|------|-------------|--------|----------|
+}
@@ -0,0 +1,186 @@
+package horologium
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExchangeRate is the rate for converting amounts from one currency to another, valid from a certain date
+// until the next rate of the same currency pair.
+type ExchangeRate struct {
+	Date time.Time // the date from which the rate is valid
+	From string    // the currency code of the converted amounts, e.g. CHF
+	To   string    // the currency code of the result, e.g. EUR
+	Rate float64   // the amount in To for one unit of From
+}
+
+// ExchangeRates is a table of dated exchange rates.
+type ExchangeRates []ExchangeRate
+
+// Sort sorts the exchange rates in ascending order by their date.
+func (e ExchangeRates) Sort() {
+	sort.SliceStable(e, func(i, j int) bool {
+		return e[i].Date.Before(e[j].Date)
+	})
+}
+
+// Rate returns the rate for converting amounts from one currency to another at the given date, i.e.
+// the latest rate of the currency pair on or before the date. If there is only a rate for the opposite
+// direction, its inverse is used. Converting an amount into its own currency always has the rate 1.
+func (e ExchangeRates) Rate(from string, to string, date time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	var result *ExchangeRate
+	inverse := false
+	for index := range e {
+		rate := &e[index]
+		matches := rate.From == from && rate.To == to
+		opposite := rate.From == to && rate.To == from
+		if (!matches && !opposite) || rate.Date.After(date) {
+			continue
+		}
+		if result == nil || !rate.Date.Before(result.Date) {
+			result = rate
+			inverse = opposite
+		}
+	}
+	if result == nil {
+		return 0, fmt.Errorf("no exchange rate from %s to %s at %s", from, to, date.Format(DateFormat))
+	}
+	if inverse {
+		return 1 / result.Rate, nil
+	}
+	return result.Rate, nil
+}
+
+// Convert converts the amount from one currency to another with the rate valid at the given date (see Rate).
+func (e ExchangeRates) Convert(amount Money, from string, to string, date time.Time) (Money, error) {
+	rate, err := e.Rate(from, to, date)
+	if err != nil {
+		return 0, err
+	}
+	return amount.Multiply(rate), nil
+}
+
+// LoadExchangeRatesFromReader reads an exchange-rate table in CSV format. Every line contains
+// the date, the source currency, the target currency, and the rate, e.g. "2023-01-01,CHF,EUR,1.0155".
+// A header line starting with "date" is skipped. The returned rates are sorted by date.
+func LoadExchangeRatesFromReader(reader io.Reader) (ExchangeRates, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = 4
+	csvReader.TrimLeadingSpace = true
+	csvReader.Comment = '#'
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not read exchange rates: %v", err)
+	}
+	result := make(ExchangeRates, 0, len(records))
+	for index, record := range records {
+		if index == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+		rate, err := parseExchangeRate(record)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse exchange rate %d: %v", index, err)
+		}
+		result = append(result, *rate)
+	}
+	result.Sort()
+	return result, nil
+}
+
+func parseExchangeRate(record []string) (*ExchangeRate, error) {
+	date, err := time.Parse(DateFormat, strings.TrimSpace(record[0]))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse date: %v", err)
+	}
+	rate, err := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rate: %v", err)
+	}
+	if rate <= 0 {
+		return nil, fmt.Errorf("rate must be positive, got %v", rate)
+	}
+	from := strings.ToUpper(strings.TrimSpace(record[1]))
+	to := strings.ToUpper(strings.TrimSpace(record[2]))
+	return &ExchangeRate{Date: date, From: from, To: to, Rate: rate}, nil
+}
+
+// Convert converts the amounts of the statistics into the given currency using the exchange rate valid at
+// the start of the statistics. Statistics without currency are assumed to be in the given currency already;
+// statistics without any amounts do not need an exchange rate.
+//
+// Since the currency format of the statistics belongs to the original currency, the converted statistics use
+// the default currency format unless the currency did not change.
+func (s Statistics) Convert(rates ExchangeRates, currency string) (Statistics, error) {
+	empty := s.Costs == 0 && s.Relief == 0 && s.OneTimeCharges == 0 && s.Payments == 0 && s.Balance == 0 && s.Budget == 0
+	if s.Currency == "" || s.Currency == currency || empty {
+		if s.Currency != "" && s.Currency != currency {
+			s.CurrencyFormat = ""
+		}
+		s.Currency = currency
+		return s, nil
+	}
+	rate, err := rates.Rate(s.Currency, currency, s.ValidFrom)
+	if err != nil {
+		return s, err
+	}
+	s.Costs = s.Costs.Multiply(rate)
+	s.Relief = s.Relief.Multiply(rate)
+	s.OneTimeCharges = s.OneTimeCharges.Multiply(rate)
+	s.Payments = s.Payments.Multiply(rate)
+	s.Balance = s.Balance.Multiply(rate)
+	s.Budget = s.Budget.Multiply(rate)
+	s.Currency = currency
+	s.CurrencyFormat = ""
+	return s, nil
+}
+
+// Convert converts every month of the statistics into the given currency (see Statistics.Convert).
+func (m MonthlyStatistics) Convert(rates ExchangeRates, currency string) (MonthlyStatistics, error) {
+	result := make(MonthlyStatistics, 0, len(m))
+	for _, stat := range m {
+		converted, err := stat.Convert(rates, currency)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// Convert converts the statistics of every series into the given currency (see Statistics.Convert),
+// such that the costs of series billed in different currencies can be summed up.
+func (p PortfolioStatistics) Convert(rates ExchangeRates, currency string) (PortfolioStatistics, error) {
+	result := PortfolioStatistics{Names: p.Names, Statistics: make([]MonthlyStatistics, 0, len(p.Statistics))}
+	for index, stats := range p.Statistics {
+		converted, err := stats.Convert(rates, currency)
+		if err != nil {
+			return result, fmt.Errorf("could not convert %s: %v", p.Names[index], err)
+		}
+		result.Statistics = append(result.Statistics, converted)
+	}
+	return result, nil
+}
+
+// Currencies returns the distinct currencies of the statistics in the order of the portfolio.
+// Statistics without currency are not considered.
+func (p PortfolioStatistics) Currencies() []string {
+	result := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, stats := range p.Statistics {
+		for _, stat := range stats {
+			if stat.Currency != "" && !seen[stat.Currency] {
+				seen[stat.Currency] = true
+				result = append(result, stat.Currency)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+const exchangeRateFile = `date,from,to,rate
+2020-02-01, CHF, EUR, 0.95
+# rates of January
+2020-01-01,chf,eur,0.9
+2020-03-01,EUR,CHF,1.25
+`
+
+func TestLoadExchangeRatesFromReader(t *testing.T) {
+	got, err := LoadExchangeRatesFromReader(strings.NewReader(exchangeRateFile))
+	require.NoError(t, err, "loading failed")
+	require.Equal(t, 3, len(got), "number of rates is wrong")
+	assert.Equal(t, ExchangeRate{Date: CreateDate(2020, 1, 1), From: "CHF", To: "EUR", Rate: 0.9}, got[0], "rates should be sorted and normalized")
+	assert.Equal(t, CreateDate(2020, 3, 1), got[2].Date, "last rate is wrong")
+
+	_, err = LoadExchangeRatesFromReader(strings.NewReader("2020-01-01,CHF,EUR"))
+	assert.EqualError(t, err, "could not read exchange rates: record on line 1: wrong number of fields", "error message wrong")
+	_, err = LoadExchangeRatesFromReader(strings.NewReader("2020-01-01,CHF,EUR,0.9\n2020-02-01,CHF,EUR,0"))
+	assert.EqualError(t, err, "could not parse exchange rate 1: rate must be positive, got 0", "error message wrong")
+	_, err = LoadExchangeRatesFromReader(strings.NewReader("2020-13-01,CHF,EUR,0.9"))
+	assert.EqualError(t, err, "could not parse exchange rate 0: could not parse date: parsing time \"2020-13-01\": month out of range", "error message wrong")
+}
+
+func TestExchangeRates_Rate(t *testing.T) {
+	rates, err := LoadExchangeRatesFromReader(strings.NewReader(exchangeRateFile))
+	require.NoError(t, err, "loading failed")
+	tests := []struct {
+		name string
+		from string
+		to   string
+		date int
+		want float64
+	}{
+		{name: "first rate", from: "CHF", to: "EUR", date: 1, want: 0.9},
+		{name: "latest rate", from: "CHF", to: "EUR", date: 2, want: 0.95},
+		{name: "inverse rate", from: "EUR", to: "CHF", date: 2, want: 1 / 0.95},
+		{name: "rate of opposite pair", from: "CHF", to: "EUR", date: 4, want: 0.8},
+		{name: "same currency", from: "USD", to: "USD", date: 1, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rates.Rate(tt.from, tt.to, CreateDate(2020, tt.date, 15))
+			require.NoError(t, err, "no rate found")
+			assert.InDelta(t, tt.want, got, 1e-9, "rate is wrong")
+		})
+	}
+	_, err = rates.Rate("CHF", "EUR", CreateDate(2019, 12, 31))
+	assert.EqualError(t, err, "no exchange rate from CHF to EUR at 2019-12-31", "error message wrong")
+	_, err = rates.Rate("USD", "EUR", CreateDate(2020, 1, 1))
+	assert.EqualError(t, err, "no exchange rate from USD to EUR at 2020-01-01", "error message wrong")
+}
+
+func testCurrencyPortfolio() Portfolio {
+	readings := MeterReadings{{Date: CreateDate(2020, 1, 1), Count: 0}, {Date: CreateDate(2020, 3, 1), Count: 0}}
+	return Portfolio{
+		{Name: "Flat", Currency: "CHF", CurrencyFormat: "%.2f CHF", PricingPlans: PricingPlans{{BasePrice: NewMoney(100)}}, MeterReadings: readings},
+		{Name: "Home", Currency: "EUR", PricingPlans: PricingPlans{{BasePrice: NewMoney(50)}}, MeterReadings: readings},
+	}
+}
+
+func TestPortfolioStatistics_Convert(t *testing.T) {
+	rates, err := LoadExchangeRatesFromReader(strings.NewReader(exchangeRateFile))
+	require.NoError(t, err, "loading failed")
+	stats := testCurrencyPortfolio().MonthlyStatistics(CreateDate(2020, 1, 1), CreateDate(2020, 3, 1))
+	assert.Equal(t, []string{"CHF", "EUR"}, stats.Currencies(), "currencies are wrong")
+	got, err := stats.Convert(rates, "EUR")
+	require.NoError(t, err, "conversion failed")
+	assert.Equal(t, []string{"EUR"}, got.Currencies(), "all statistics should be in EUR")
+	assert.Equal(t, NewMoney(90), got.Statistics[0][0].Costs, "costs of January are wrong")
+	assert.Equal(t, NewMoney(95), got.Statistics[0][1].Costs, "costs of February are wrong")
+	assert.Equal(t, "", got.Statistics[0][0].CurrencyFormat, "the format of the original currency must not be used")
+	assert.Equal(t, NewMoney(90+95+50+50), got.TotalCosts(), "total costs are wrong")
+	assert.Equal(t, NewMoney(100), stats.Statistics[0][0].Costs, "original statistics must not be changed")
+
+	_, err = stats.Convert(rates, "USD")
+	assert.EqualError(t, err, "could not convert Flat: no exchange rate from CHF to USD at 2020-01-01", "error message wrong")
+}
+
+func TestStatistics_Convert_Budget(t *testing.T) {
+	rates := ExchangeRates{{Date: CreateDate(2020, 1, 1), From: "EUR", To: "CHF", Rate: 2}}
+	stat := Statistics{ValidFrom: CreateDate(2020, 1, 1), Currency: "EUR", Costs: NewMoney(10), Budget: NewMoney(10)}
+	got, err := stat.Convert(rates, "CHF")
+	require.NoError(t, err, "conversion failed")
+	assert.Equal(t, NewMoney(20), got.Costs, "costs are wrong")
+	assert.Equal(t, NewMoney(20), got.Budget, "budget should be converted as well")
+
+	_, err = Statistics{ValidFrom: CreateDate(2020, 1, 1), Currency: "EUR", Budget: NewMoney(10)}.Convert(rates, "USD")
+	assert.EqualError(t, err, "no exchange rate from EUR to USD at 2020-01-01", "a budget alone needs an exchange rate")
+}
+
+func ExamplePortfolioStatistics_Convert() {
+	rates := ExchangeRates{{Date: CreateDate(2020, 1, 1), From: "CHF", To: "EUR", Rate: 0.9}}
+	stats := testCurrencyPortfolio().MonthlyStatistics(CreateDate(2020, 1, 1), CreateDate(2020, 3, 1))
+	converted, _ := stats.Convert(rates, "EUR")
+	converted.RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR |  Flat  |  Home  | TOTAL  |
+	// |-----------|------|--------|--------|--------|
+	// | January   | 2020 |  90.00 |  50.00 | 140.00 |
+	// | February  |      |  90.00 |  50.00 | 140.00 |
+	// |-----------|------|--------|--------|--------|
+	// | TOTAL     |      | 180.00 | 100.00 | 280.00 |
+	// |-----------|------|--------|--------|--------|
+}
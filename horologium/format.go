@@ -0,0 +1,430 @@
+package horologium
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/goccy/go-yaml/lexer"
+	"github.com/goccy/go-yaml/token"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format reads a series file from the reader and writes it in a canonical layout to the writer.
+//
+// The canonical layout sorts the meter readings (see MeterReadings.Sort) and the pricing plans (see PricingPlans.Sort),
+// writes all dates in the DateFormat, and puts every plan and reading on a single line in flow style.
+// Comments of the original file are preserved and stay attached to the key, plan, or reading they belong to;
+// comments on their own line within an item that spans several lines are written above the item.
+// Formatting an already formatted file yields the file unchanged.
+func Format(reader io.Reader, writer io.Writer) error {
+	buf := new(bytes.Buffer)
+	_, err := buf.ReadFrom(reader)
+	if err != nil {
+		return fmt.Errorf("could not read reader: %v", err)
+	}
+	series, err := LoadFromReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return err
+	}
+	comments := collectComments(buf.String())
+	out := canonicalWriter{writer: writer, comments: comments}
+	out.scalar("name", quote(series.Name), series.Name != "")
+	out.scalar("consumptionFormat", quote(series.ConsumptionFormat), series.ConsumptionFormat != "")
+	out.scalar("meterFormat", quote(series.MeterFormat), series.MeterFormat != "")
+	out.scalar("unit", quote(series.Unit.Symbol), series.Unit.Known())
+	out.scalar("meterUnit", quote(series.MeterUnit.Symbol), series.MeterUnit.Known())
+	out.scalar("currencyFormat", quote(series.CurrencyFormat), series.CurrencyFormat != "")
+	out.scalar("currency", quote(series.Currency), series.Currency != "")
+	out.scalar("rounding", series.Rounding.flowMapping(), series.Rounding.Stage != RoundNever)
+	interpolation, known := interpolationFlowMapping(series.Interpolation)
+	out.scalar("interpolation", interpolation, known)
+	out.scalar("heating", "true", series.Heating)
+	out.scalar("ignoreEstimates", "true", series.IgnoreEstimates)
+	if series.ReadingInterval != nil {
+		out.scalar("readingInterval", series.ReadingInterval.flowMapping(), true)
+	}
+	out.list("plans", len(series.PricingPlans), func(i int) time.Time {
+		if series.PricingPlans[i].ValidFrom == nil {
+			return time.Time{}
+		}
+		return *series.PricingPlans[i].ValidFrom
+	}, func(i int) string { return series.PricingPlans[i].flowMapping() })
+	out.list("readings", len(series.MeterReadings), func(i int) time.Time { return series.MeterReadings[i].Date },
+		func(i int) string { return series.MeterReadings[i].flowMapping() })
+	out.list("advancePayments", len(series.AdvancePayments), func(i int) time.Time { return series.AdvancePayments[i].ValidFrom },
+		func(i int) string { return series.AdvancePayments[i].flowMapping() })
+	out.list("priceCaps", len(series.PriceCaps), func(i int) time.Time { return series.PriceCaps[i].ValidFrom },
+		func(i int) string { return series.PriceCaps[i].flowMapping() })
+	out.list("reliefCredits", len(series.ReliefCredits), func(i int) time.Time { return series.ReliefCredits[i].Date },
+		func(i int) string { return series.ReliefCredits[i].flowMapping() })
+	out.list("oneTimeCharges", len(series.OneTimeCharges), func(i int) time.Time { return series.OneTimeCharges[i].Date },
+		func(i int) string { return series.OneTimeCharges[i].flowMapping() })
+	out.list("conversionFactors", len(series.ConversionFactors), func(i int) time.Time { return series.ConversionFactors[i].ValidFrom },
+		func(i int) string { return series.ConversionFactors[i].flowMapping() })
+	out.list("emissionFactors", len(series.EmissionFactors), func(i int) time.Time { return series.EmissionFactors[i].ValidFrom },
+		func(i int) string { return series.EmissionFactors[i].flowMapping() })
+	out.list("budgets", len(series.Budgets), func(i int) time.Time { return series.Budgets[i].ValidFrom },
+		func(i int) string { return series.Budgets[i].flowMapping() })
+	out.list("goals", len(series.Goals), func(i int) time.Time { return series.Goals[i].ValidFrom },
+		func(i int) string { return series.Goals[i].flowMapping() })
+	out.list("contracts", len(series.Contracts), func(i int) time.Time { return series.Contracts[i].ValidFrom },
+		func(i int) string { return series.Contracts[i].flowMapping() })
+	out.list("meters", len(series.Meters), func(i int) time.Time { return series.Meters[i].Installed },
+		func(i int) string { return series.Meters[i].flowMapping() })
+	out.list("events", len(series.Events), func(i int) time.Time { return series.Events[i].ValidFrom },
+		func(i int) string { return series.Events[i].flowMapping() })
+	out.list("households", len(series.Households), func(i int) time.Time { return series.Households[i].ValidFrom },
+		func(i int) string { return series.Households[i].flowMapping() })
+	out.footer()
+	return out.err
+}
+
+func (p *PricingPlan) flowMapping() string {
+	fields := []string{"name", quote(p.Name), "basePrice", p.BasePrice.String(), "unitPrice", p.UnitPrice.String()}
+	if p.ValidFrom != nil {
+		fields = append(fields, "validFrom", formatDate(p.ValidFrom))
+	}
+	if p.ValidTo != nil {
+		fields = append(fields, "validTo", formatDate(p.ValidTo))
+	}
+	return flowMapping(fields...)
+}
+
+func (m *MeterReading) flowMapping() string {
+	keysAndValues := []string{"date", m.Date.Format(DateFormat), "count", formatNumber(m.Count)}
+	if m.Source != ReadingActual {
+		keysAndValues = append(keysAndValues, "source", m.Source.String())
+	}
+	if m.Note != "" {
+		keysAndValues = append(keysAndValues, "note", quote(m.Note))
+	}
+	return flowMapping(keysAndValues...)
+}
+
+func (a *AdvancePayment) flowMapping() string {
+	fields := []string{"amount", a.Amount.String(), "validFrom", a.ValidFrom.Format(DateFormat)}
+	if a.ValidTo != nil {
+		fields = append(fields, "validTo", formatDate(a.ValidTo))
+	}
+	return flowMapping(fields...)
+}
+
+func (p *PriceCap) flowMapping() string {
+	fields := []string{"name", quote(p.Name), "cappedPrice", p.CappedPrice.String(), "quota", formatNumber(p.Quota),
+		"referenceConsumption", formatNumber(p.ReferenceConsumption), "validFrom", p.ValidFrom.Format(DateFormat)}
+	if p.ValidTo != nil {
+		fields = append(fields, "validTo", formatDate(p.ValidTo))
+	}
+	return flowMapping(fields...)
+}
+
+func (r *ReliefCredit) flowMapping() string {
+	return flowMapping("name", quote(r.Name), "amount", r.Amount.String(), "date", r.Date.Format(DateFormat))
+}
+
+func (o *OneTimeCharge) flowMapping() string {
+	fields := []string{"name", quote(o.Name), "amount", o.Amount.String(), "date", o.Date.Format(DateFormat)}
+	if o.Months > 1 {
+		fields = append(fields, "months", strconv.Itoa(o.Months))
+	}
+	return flowMapping(fields...)
+}
+
+func (c *ConversionFactor) flowMapping() string {
+	return flowMapping("calorificValue", formatNumber(c.CalorificValue), "stateNumber", formatNumber(c.StateNumber), "validFrom", c.ValidFrom.Format(DateFormat))
+}
+
+func (e *EmissionFactor) flowMapping() string {
+	return flowMapping("emission", formatNumber(e.Emission), "primaryEnergy", formatNumber(e.PrimaryEnergy), "validFrom", e.ValidFrom.Format(DateFormat))
+}
+
+func (r *RoundingRule) flowMapping() string {
+	stage, mode := "", ""
+	for name, value := range roundingStages {
+		if value == r.Stage {
+			stage = name
+		}
+	}
+	for name, value := range roundingModes {
+		if value == r.Mode {
+			mode = name
+		}
+	}
+	return flowMapping("stage", stage, "mode", mode, "decimals", strconv.Itoa(r.Decimals))
+}
+
+func (b *Budget) flowMapping() string {
+	fields := make([]string, 0, 8)
+	if len(b.Monthly) == 0 {
+		fields = append(fields, "amount", b.Amount.String())
+	} else {
+		amounts := make([]string, 0, len(b.Monthly))
+		for _, amount := range b.Monthly {
+			amounts = append(amounts, amount.String())
+		}
+		fields = append(fields, "monthly", "["+strings.Join(amounts, ", ")+"]")
+	}
+	fields = append(fields, "validFrom", b.ValidFrom.Format(DateFormat))
+	if b.ValidTo != nil {
+		fields = append(fields, "validTo", formatDate(b.ValidTo))
+	}
+	return flowMapping(fields...)
+}
+
+func (g *Goal) flowMapping() string {
+	return flowMapping("name", quote(g.Name), "baselineFrom", g.BaselineFrom.Format(DateFormat), "baselineTo", g.BaselineTo.Format(DateFormat),
+		"reduction", formatNumber(math.Round(g.Reduction*1e6)/1e4), "validFrom", g.ValidFrom.Format(DateFormat), "validTo", g.ValidTo.Format(DateFormat))
+}
+
+func (r *ReadingInterval) flowMapping() string {
+	fields := []string{"months", strconv.Itoa(r.Months)}
+	if r.Day != 0 {
+		fields = append(fields, "day", strconv.Itoa(r.Day))
+	}
+	if r.Tolerance != 0 {
+		fields = append(fields, "tolerance", strconv.Itoa(r.Tolerance))
+	}
+	return flowMapping(fields...)
+}
+
+func (c *Contract) flowMapping() string {
+	fields := make([]string, 0, 16)
+	if c.Provider != "" {
+		fields = append(fields, "provider", quote(c.Provider))
+	}
+	if c.CustomerNumber != "" {
+		fields = append(fields, "customerNumber", quote(c.CustomerNumber))
+	}
+	if c.MeterNumber != "" {
+		fields = append(fields, "meterNumber", quote(c.MeterNumber))
+	}
+	fields = append(fields, "validFrom", c.ValidFrom.Format(DateFormat))
+	if !c.MinimumTerm.IsZero() {
+		fields = append(fields, "minimumTerm", c.MinimumTerm.String())
+	}
+	if !c.NoticePeriod.IsZero() {
+		fields = append(fields, "noticePeriod", c.NoticePeriod.String())
+	}
+	if !c.Renewal.IsZero() {
+		fields = append(fields, "renewal", c.Renewal.String())
+	}
+	if c.PriceGuaranteeEnd != nil {
+		fields = append(fields, "priceGuaranteeEnd", formatDate(c.PriceGuaranteeEnd))
+	}
+	return flowMapping(fields...)
+}
+
+func (e *Event) flowMapping() string {
+	fields := []string{"label", quote(e.Label), "validFrom", e.ValidFrom.Format(DateFormat), "validTo", e.ValidTo.Format(DateFormat)}
+	if e.Exclude {
+		fields = append(fields, "exclude", "true")
+	}
+	return flowMapping(fields...)
+}
+
+func (h *Household) flowMapping() string {
+	fields := make([]string, 0, 6)
+	if h.Occupants != 0 {
+		fields = append(fields, "occupants", strconv.Itoa(h.Occupants))
+	}
+	if h.Area != 0 {
+		fields = append(fields, "area", formatNumber(h.Area))
+	}
+	return flowMapping(append(fields, "validFrom", h.ValidFrom.Format(DateFormat))...)
+}
+
+func (m *Meter) flowMapping() string {
+	fields := []string{"serialNumber", quote(m.SerialNumber), "installed", m.Installed.Format(DateFormat)}
+	if m.CalibrationExpires != nil {
+		fields = append(fields, "calibrationExpires", formatDate(m.CalibrationExpires))
+	}
+	return flowMapping(fields...)
+}
+
+// interpolationFlowMapping renders the interpolations that can be stored in series files;
+// other interpolations are reported as unknown.
+func interpolationFlowMapping(interpolation Interpolation) (string, bool) {
+	switch value := interpolation.(type) {
+	case LinearInterpolation:
+		return flowMapping("method", "linear"), true
+	case StepInterpolation:
+		return flowMapping("method", "step"), true
+	case DegreeDayInterpolation:
+		return flowMapping("method", "degreeDays"), true
+	case ProfileInterpolation:
+		switch profile := value.Profile.(type) {
+		case H0Profile:
+			return flowMapping("method", "profile", "profile", "h0"), true
+		case MonthlyProfile:
+			weights := make([]string, 0, len(profile))
+			for _, weight := range profile {
+				weights = append(weights, formatNumber(weight))
+			}
+			return flowMapping("method", "profile", "weights", "["+strings.Join(weights, ", ")+"]"), true
+		}
+	}
+	return "", false
+}
+
+// flowMapping renders alternating keys and values as a single-line yaml mapping, e.g. {date: 2020-01-01, count: 12}.
+func flowMapping(keysAndValues ...string) string {
+	entries := make([]string, 0, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i = i + 2 {
+		entries = append(entries, keysAndValues[i]+": "+keysAndValues[i+1])
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+func quote(text string) string {
+	return strconv.Quote(text)
+}
+
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(DateFormat)
+}
+
+// comment holds the comments belonging to a key or a list item of a series file.
+type comment struct {
+	leading  []string // full-line comments directly above the element
+	trailing string   // the comment at the end of the element's line
+}
+
+type commentAnchor struct {
+	section string // the top-level key the element belongs to
+	index   int    // the index of the list item within the section, or -1 for the key itself
+}
+
+type fileComments struct {
+	anchors map[commentAnchor]*comment
+	footer  []string // comments after the last element of the file
+}
+
+func (f *fileComments) item(section string, index int) *comment {
+	return f.anchors[commentAnchor{section: section, index: index}]
+}
+
+// collectComments assigns every comment in the source to the top-level key or
+// list item it belongs to. A comment on its own line belongs to the next element,
+// a comment after an element belongs to the element on the same line. Within a list item
+// that spans several lines, a comment on its own line belongs to the item.
+func collectComments(source string) *fileComments {
+	result := &fileComments{anchors: make(map[commentAnchor]*comment)}
+	tokens := lexer.Tokenize(source)
+	var current *comment
+	pending := make([]string, 0)
+	section := ""
+	itemColumn := -1
+	itemIndex := 0
+	inItem := false
+	lastLine := -1
+	anchor := func(key commentAnchor) {
+		current = &comment{leading: pending}
+		result.anchors[key] = current
+		pending = make([]string, 0)
+	}
+	for index, tk := range tokens {
+		switch {
+		case tk.Type == token.CommentType:
+			text := "#" + tk.Value
+			if tk.Position.Line == lastLine && current != nil {
+				current.trailing = strings.TrimSpace(current.trailing + " " + text)
+			} else {
+				pending = append(pending, text)
+			}
+			continue
+		case tk.Position.Column == 1 && index+1 < len(tokens) && tokens[index+1].Type == token.MappingValueType:
+			section = tk.Value
+			itemColumn = -1
+			itemIndex = 0
+			inItem = false
+			anchor(commentAnchor{section: section, index: -1})
+		case tk.Type == token.SequenceEntryType && section != "" && (itemColumn == -1 || itemColumn == tk.Position.Column):
+			itemColumn = tk.Position.Column
+			inItem = true
+			anchor(commentAnchor{section: section, index: itemIndex})
+			itemIndex = itemIndex + 1
+		case inItem && len(pending) > 0:
+			// the comment is followed by another line of the current item
+			current.leading = append(current.leading, pending...)
+			pending = make([]string, 0)
+		}
+		lastLine = tk.Position.Line
+	}
+	result.footer = pending
+	return result
+}
+
+type canonicalWriter struct {
+	writer   io.Writer
+	comments *fileComments
+	err      error
+}
+
+func (c *canonicalWriter) scalar(key string, value string, present bool) {
+	if present {
+		c.line("", c.comments.item(key, -1), key+": "+value)
+	}
+}
+
+func (c *canonicalWriter) section(key string, present bool) {
+	if present {
+		c.line("", c.comments.item(key, -1), key+":")
+	}
+}
+
+func (c *canonicalWriter) item(comment *comment, value string) {
+	c.line("  ", comment, "- "+value)
+}
+
+// list writes a section with its list items in the canonical order, i.e. stably sorted by the date of every item
+// like the Sort methods of the lists. The comments are looked up by the index of the item in the original file,
+// so items that look the same, e.g. two identical readings, keep their own comments.
+func (c *canonicalWriter) list(section string, length int, date func(index int) time.Time, flowMapping func(index int) string) {
+	order := make([]int, length)
+	for index := range order {
+		order[index] = index
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return date(order[i]).Before(date(order[j]))
+	})
+	c.section(section, length > 0)
+	for _, index := range order {
+		c.item(c.comments.item(section, index), flowMapping(index))
+	}
+}
+
+func (c *canonicalWriter) footer() {
+	for _, text := range c.comments.footer {
+		c.write(text)
+	}
+}
+
+func (c *canonicalWriter) line(indent string, comment *comment, text string) {
+	if comment != nil {
+		for _, leading := range comment.leading {
+			c.write(indent + leading)
+		}
+		if comment.trailing != "" {
+			text = text + " " + comment.trailing
+		}
+	}
+	c.write(indent + text)
+}
+
+func (c *canonicalWriter) write(line string) {
+	if c.err != nil {
+		return
+	}
+	_, c.err = fmt.Fprintln(c.writer, line)
+}
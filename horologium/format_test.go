@@ -0,0 +1,171 @@
+package horologium
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+const unformattedFile = `# Power meter in the basement
+name:   'Power'
+currencyFormat: "%.2f Euro"
+readings:
+  - {date: "2020-02-01", count: 1223.34} # read by the landlord
+  # estimated
+  - date: 2020-01-01
+    count: 1201.2300
+plans: [{name: 2020, basePrice: 10, unitPrice: 0.25, validFrom: "2020-01-01"},
+  {name: 2019, basePrice: 9.5, unitPrice: 0.24, validFrom: 2019-01-01, validTo: "2020-01-01"}]
+# end of file
+`
+
+func ExampleFormat() {
+	err := Format(strings.NewReader(unformattedFile), os.Stdout)
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// # Power meter in the basement
+	// name: "Power"
+	// currencyFormat: "%.2f Euro"
+	// plans:
+	//   - {name: "2019", basePrice: 9.5, unitPrice: 0.24, validFrom: 2019-01-01, validTo: 2020-01-01}
+	//   - {name: "2020", basePrice: 10, unitPrice: 0.25, validFrom: 2020-01-01}
+	// readings:
+	//   # estimated
+	//   - {date: 2020-01-01, count: 1201.23}
+	//   - {date: 2020-02-01, count: 1223.34} # read by the landlord
+	// # end of file
+}
+
+func TestFormat_Idempotent(t *testing.T) {
+	first := new(bytes.Buffer)
+	err := Format(strings.NewReader(unformattedFile), first)
+	require.NoError(t, err, "formatting the file failed")
+	second := new(bytes.Buffer)
+	err = Format(strings.NewReader(first.String()), second)
+	require.NoError(t, err, "formatting the formatted file failed")
+	assert.Equal(t, first.String(), second.String(), "formatting a formatted file must not change it")
+}
+
+func TestFormat_DuplicateComments(t *testing.T) {
+	file := `readings:
+  - {date: 2020-03-01, count: 30} # estimate
+  - {date: 2020-01-01, count: 10} # estimate
+  # read by the landlord
+  - {date: 2020-01-01, count: 10}
+  - date: 2020-02-01
+    # photo in the folder
+    count: 20
+`
+	want := `readings:
+  - {date: 2020-01-01, count: 10} # estimate
+  # read by the landlord
+  - {date: 2020-01-01, count: 10}
+  # photo in the folder
+  - {date: 2020-02-01, count: 20}
+  - {date: 2020-03-01, count: 30} # estimate
+`
+	got := new(bytes.Buffer)
+	err := Format(strings.NewReader(file), got)
+	require.NoError(t, err, "formatting the file failed")
+	assert.Equal(t, want, got.String(), "every item should keep its own comments")
+	again := new(bytes.Buffer)
+	err = Format(strings.NewReader(got.String()), again)
+	require.NoError(t, err, "formatting the formatted file failed")
+	assert.Equal(t, want, again.String(), "formatting a formatted file must not change it")
+}
+
+func TestFormat_Error(t *testing.T) {
+	err := Format(&errReader{}, new(bytes.Buffer))
+	assert.EqualError(t, err, "could not read reader: test error", "error message wrong")
+	err = Format(strings.NewReader("readings:\n  - {date: 2020-13-01, count: 3}"), new(bytes.Buffer))
+	assert.EqualError(t, err, "could not parse reading 0: could not parse date: parsing time \"2020-13-01\": month out of range", "error message wrong")
+}
+
+func TestPricingPlans_Sort(t *testing.T) {
+	plans := PricingPlans{
+		{Name: "b", ValidFrom: formatDatePtr(2020, 1, 1)},
+		{Name: "c", ValidFrom: nil},
+		{Name: "a", ValidFrom: formatDatePtr(2019, 1, 1)},
+	}
+	plans.Sort()
+	assert.Equal(t, "c", plans[0].Name, "plan without validFrom should be first")
+	assert.Equal(t, "a", plans[1].Name, "plans should be sorted by validFrom")
+	assert.Equal(t, "b", plans[2].Name, "plans should be sorted by validFrom")
+}
+
+const completeFile = `name: "Power"
+consumptionFormat: "%.1f kWh"
+meterFormat: "%.2f m³"
+unit: "kWh"
+meterUnit: "m3"
+currencyFormat: "%.2f €"
+currency: "EUR"
+rounding: {stage: monthly, mode: halfEven}
+interpolation: {method: profile, weights: [16, 14, 11, 8, 5, 3, 2, 2, 4, 8, 12, 15]}
+heating: true
+ignoreEstimates: true
+readingInterval: {months: 1, day: 1, tolerance: 3}
+plans:
+  - {name: "2023", basePrice: 12, unitPrice: 0.42, validFrom: 2023-01-01}
+readings:
+  - {date: 2023-02-01, count: 350.5}
+  - {date: 2023-01-01, count: 100}
+  - {date: 2023-01-15, count: 200, source: estimated, note: "meter, not accessible"}
+advancePayments:
+  - {amount: 90, validFrom: 2023-01-01}
+priceCaps:
+  - {name: "Price brake", cappedPrice: 0.4, quota: 0.8, referenceConsumption: 3500, validFrom: 2023-01-01, validTo: 2024-01-01}
+reliefCredits:
+  - {name: "Relief", amount: 120.5, date: 2022-12-01}
+oneTimeCharges:
+  - {name: "Connection fee", amount: 49.9, date: 2023-01-01}
+  - {name: "Bonus", amount: -120, date: 2023-01-15, months: 12}
+conversionFactors:
+  - {calorificValue: 11.2, validFrom: 2023-01-01}
+  - {calorificValue: 11.1, stateNumber: 0.9533, validFrom: 2022-01-01}
+emissionFactors:
+  - {emission: 201, primaryEnergy: 1.1, validFrom: 2023-01-01}
+  - {emission: 202.5, primaryEnergy: 1.1, validFrom: 2022-01-01}
+budgets:
+  - {monthly: [120, 110, 90, 70, 60, 50, 50, 50, 60, 80, 100, 115.5], validFrom: 2023-01-01, validTo: 2024-01-01}
+  - {amount: 80, validFrom: 2022-01-01}
+contracts:
+  - {provider: "Power & Co", customerNumber: "4711", meterNumber: "1ESY1160123", validFrom: 2023-01-01, minimumTerm: 1y, noticePeriod: 6w, renewal: 12m, priceGuaranteeEnd: 2024-01-01}
+  - {provider: "Old provider", validFrom: 2020-01-01, noticePeriod: 1m}
+meters:
+  - {serialNumber: "1ESY1160123", installed: 2023-01-01, calibrationExpires: 2031-01-01}
+  - {serialNumber: "0815", installed: 2015-03-01}
+households:
+  - {occupants: 3, area: 92.5, validFrom: 2023-04-15}
+  - {occupants: 2, validFrom: 2020-01-01}
+events:
+  - {label: "Renovation", validFrom: 2023-03-01, validTo: 2023-04-15, exclude: true}
+  - {label: "Guests", validFrom: 2023-01-20, validTo: 2023-01-27}
+goals:
+  - {name: "Save power", baselineFrom: 2022-01-01, baselineTo: 2023-01-01, reduction: 12.5, validFrom: 2023-01-01, validTo: 2024-01-01}
+`
+
+func TestFormat_RoundTrip(t *testing.T) {
+	want, err := LoadFromReader(strings.NewReader(completeFile))
+	require.NoError(t, err, "loading the original file failed")
+	want.MeterReadings.Sort()
+	want.ConversionFactors.Sort()
+	want.EmissionFactors.Sort()
+	want.Budgets.Sort()
+	want.Goals.Sort()
+	want.Contracts.Sort()
+	want.Meters.Sort()
+	want.Events.Sort()
+	want.Households.Sort()
+	formatted := new(bytes.Buffer)
+	err = Format(strings.NewReader(completeFile), formatted)
+	require.NoError(t, err, "formatting the file failed")
+	got, err := LoadFromReader(formatted)
+	require.NoError(t, err, "loading the formatted file failed")
+	assert.Equal(t, want, got, "formatting must not change the content of the file")
+}
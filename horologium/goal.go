@@ -0,0 +1,175 @@
+package horologium
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// Goal is a commitment to reduce the consumption of a target period compared with a baseline period,
+// e.g. to use 10 % less power in 2023 than in 2022.
+type Goal struct {
+	Name         string
+	BaselineFrom time.Time // the start of the baseline period
+	BaselineTo   time.Time // the end of the baseline period (exclusive)
+	Reduction    float64   // the reduction compared with the baseline, e.g. 0.1 for 10 %
+	ValidFrom    time.Time // the start of the target period
+	ValidTo      time.Time // the end of the target period (exclusive)
+}
+
+// Goals is a slice of goals.
+type Goals []Goal
+
+// Sort sorts the goals in ascending order by the start of their target period.
+func (g Goals) Sort() {
+	sort.SliceStable(g, func(i, j int) bool {
+		return g[i].ValidFrom.Before(g[j].ValidFrom)
+	})
+}
+
+// GoalPoint compares the cumulative consumption since the start of the target period with the target at a date.
+type GoalPoint struct {
+	Date        time.Time
+	Consumption float64 // the consumption from the start of the target period until the date
+	Target      float64 // the pro-rated target from the start of the target period until the date
+}
+
+// GoalProgress is the progress of a series towards a goal at a certain date.
+type GoalProgress struct {
+	Goal              Goal
+	Date              time.Time   // the date of the report, at most the end of the target period and the last meter reading
+	Baseline          float64     // the consumption in the baseline period
+	Target            float64     // the consumption allowed in the whole target period
+	Points            []GoalPoint // one point at the end of every month of the target period up to the date
+	ConsumptionFormat string
+}
+
+// Progress computes the progress towards the goal at the given date. Since the consumption after the last meter
+// reading is unknown, the report ends at the last reading if the readings do not reach the date.
+//
+// The target is the baseline consumption reduced by the goal and scaled to the length of the target period.
+// It is pro-rated over the target period with the load profile of the series, i.e. with the degree days of heating
+// series (see Series.UseDegreeDays) or the profile of the interpolation, and linearly otherwise. Periods of excluded
+// events (see Event) in the baseline period do not count for the target. If the meter readings do not cover the
+// baseline period, an error is returned.
+func (s *Series) Progress(goal Goal, date time.Time) (*GoalProgress, error) {
+	sorted := append(MeterReadings{}, s.MeterReadings...)
+	sorted.Sort()
+	if len(sorted) < 2 || sorted[0].Date.After(goal.BaselineFrom) || sorted[len(sorted)-1].Date.Before(goal.BaselineTo) {
+		return nil, fmt.Errorf("the meter readings do not cover the baseline period from %s to %s",
+			goal.BaselineFrom.Format(DateFormat), goal.BaselineTo.Format(DateFormat))
+	}
+	if date.After(goal.ValidTo) {
+		date = goal.ValidTo
+	}
+	if last := sorted[len(sorted)-1].Date; last.Before(date) {
+		date = last
+	}
+	baseline := s.Consumption(goal.BaselineFrom, goal.BaselineTo)
+	typical, baselineHours := s.typicalConsumption(goal.BaselineFrom, goal.BaselineTo)
+	target := 0.0
+	if baselineHours > 0 {
+		target = typical * (1 - goal.Reduction) * goal.ValidTo.Sub(goal.ValidFrom).Hours() / baselineHours
+	}
+	result := &GoalProgress{Goal: goal, Date: date, Baseline: baseline, Target: target, ConsumptionFormat: s.ConsumptionFormat}
+	for pointDate := goal.ValidFrom; pointDate.Before(date); {
+		pointDate = firstOfNextMonth(pointDate)
+		if date.Before(pointDate) {
+			pointDate = date
+		}
+		result.Points = append(result.Points, GoalPoint{
+			Date:        pointDate,
+			Consumption: s.Consumption(goal.ValidFrom, pointDate),
+			Target:      target * s.targetShare(goal, pointDate),
+		})
+	}
+	return result, nil
+}
+
+// targetShare returns the share of the target of the goal that falls between the start of the target period
+// and the date, weighted by the load profile of the series.
+func (s *Series) targetShare(goal Goal, date time.Time) float64 {
+	var profile LoadProfile
+	if s.DegreeDays != nil {
+		profile = s.DegreeDays
+	} else if interpolation, ok := s.Interpolation.(ProfileInterpolation); ok {
+		profile = interpolation.Profile
+	} else if interpolation, ok := s.Interpolation.(DegreeDayInterpolation); ok && interpolation.DegreeDays != nil {
+		profile = interpolation.DegreeDays
+	}
+	if profile != nil {
+		weights := ProfileInterpolation{Profile: profile}
+		if total := weights.weightBetween(goal.ValidFrom, goal.ValidTo); total > 0 {
+			return weights.weightBetween(goal.ValidFrom, date) / total
+		}
+	}
+	return date.Sub(goal.ValidFrom).Hours() / goal.ValidTo.Sub(goal.ValidFrom).Hours()
+}
+
+// Consumption returns the consumption from the start of the target period until the date of the report.
+func (g *GoalProgress) Consumption() float64 {
+	if len(g.Points) == 0 {
+		return 0
+	}
+	return g.Points[len(g.Points)-1].Consumption
+}
+
+// Expected returns the pro-rated target from the start of the target period until the date of the report.
+func (g *GoalProgress) Expected() float64 {
+	if len(g.Points) == 0 {
+		return 0
+	}
+	return g.Points[len(g.Points)-1].Target
+}
+
+// OnTrack returns whether the consumption so far does not exceed the pro-rated target.
+func (g *GoalProgress) OnTrack() bool {
+	return g.Consumption() <= g.Expected()
+}
+
+// RemainingDays returns the number of days from the date of the report until the end of the target period.
+func (g *GoalProgress) RemainingDays() int {
+	start := g.Date
+	if start.Before(g.Goal.ValidFrom) {
+		start = g.Goal.ValidFrom
+	}
+	return int(math.Round(g.Goal.ValidTo.Sub(start).Hours() / 24))
+}
+
+// DailyAllowance returns the consumption per day left for the rest of the target period to reach the goal.
+// The allowance is negative if the target is already exceeded and zero after the end of the target period.
+func (g *GoalProgress) DailyAllowance() float64 {
+	days := g.RemainingDays()
+	if days <= 0 {
+		return 0
+	}
+	return (g.Target - g.Consumption()) / float64(days)
+}
+
+// Render writes a table with the cumulative consumption and target at the end of every month,
+// followed by a summary with the daily allowance for the rest of the target period.
+func (g *GoalProgress) Render(writer io.Writer) {
+	format := func(value float64) string {
+		stat := Statistics{Consumption: value, ConsumptionFormat: g.ConsumptionFormat}
+		return stat.FormatConsumption()
+	}
+	columns := []tableColumn{{header: "DATE", left: true}, {header: "CONSUMPTION"}, {header: "TARGET"}, {header: "DIFFERENCE"}}
+	rows := make([][]string, 0, len(g.Points))
+	for _, point := range g.Points {
+		rows = append(rows, []string{point.Date.Format(DateFormat), format(point.Consumption), format(point.Target), format(point.Consumption - point.Target)})
+	}
+	renderTable(writer, columns, rows, nil)
+	_, _ = fmt.Fprintf(writer, "Target from %s to %s: %s (%.0f %% less than %s from %s to %s)\n",
+		g.Goal.ValidFrom.Format(DateFormat), g.Goal.ValidTo.Format(DateFormat), format(g.Target), g.Goal.Reduction*100,
+		format(g.Baseline), g.Goal.BaselineFrom.Format(DateFormat), g.Goal.BaselineTo.Format(DateFormat))
+	status := "on track"
+	if !g.OnTrack() {
+		status = "behind"
+	}
+	_, _ = fmt.Fprintf(writer, "Progress: %s of %s used so far (%s)\n", format(g.Consumption()), format(g.Expected()), status)
+	if days := g.RemainingDays(); days > 0 {
+		_, _ = fmt.Fprintf(writer, "Daily allowance for the remaining %d days: %s\n", days, format(g.DailyAllowance()))
+	}
+}
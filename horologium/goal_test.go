@@ -0,0 +1,96 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testGoalSeries() (*Series, Goal) {
+	series := &Series{MeterReadings: MeterReadings{
+		{Date: CreateDate(2022, 1, 1), Count: 0},
+		{Date: CreateDate(2023, 1, 1), Count: 3650},
+		{Date: CreateDate(2023, 3, 1), Count: 4240},
+	}}
+	goal := Goal{Name: "Save power", BaselineFrom: CreateDate(2022, 1, 1), BaselineTo: CreateDate(2023, 1, 1), Reduction: 0.1,
+		ValidFrom: CreateDate(2023, 1, 1), ValidTo: CreateDate(2024, 1, 1)}
+	return series, goal
+}
+
+func TestSeries_Progress(t *testing.T) {
+	series, goal := testGoalSeries()
+	got, err := series.Progress(goal, CreateDate(2023, 3, 1))
+	require.NoError(t, err, "progress failed")
+	assert.Equal(t, 3650.0, got.Baseline, "baseline is wrong")
+	assert.InDelta(t, 3285, got.Target, 1e-9, "target is wrong")
+	require.Equal(t, 2, len(got.Points), "there should be one point per month")
+	assert.Equal(t, CreateDate(2023, 2, 1), got.Points[0].Date, "date of the first point is wrong")
+	assert.InDelta(t, 310, got.Points[0].Consumption, 1e-9, "cumulative consumption is wrong")
+	assert.InDelta(t, 279, got.Points[0].Target, 1e-9, "pro-rated target is wrong")
+	assert.InDelta(t, 590, got.Consumption(), 1e-9, "consumption so far is wrong")
+	assert.False(t, got.OnTrack(), "the goal should be missed so far")
+	assert.Equal(t, 306, got.RemainingDays(), "remaining days are wrong")
+	assert.InDelta(t, (3285-590)/306.0, got.DailyAllowance(), 1e-9, "daily allowance is wrong")
+
+	series.MeterReadings = append(series.MeterReadings, MeterReading{Date: CreateDate(2024, 2, 1), Count: 5000})
+	got, err = series.Progress(goal, CreateDate(2025, 1, 1))
+	require.NoError(t, err, "progress failed")
+	assert.Equal(t, CreateDate(2024, 1, 1), got.Date, "the date should be limited to the target period")
+	assert.Equal(t, 0.0, got.DailyAllowance(), "after the target period, there is no allowance")
+	assert.True(t, got.OnTrack(), "without further consumption, the goal should be reached")
+}
+
+func TestSeries_Progress_LastReading(t *testing.T) {
+	series, goal := testGoalSeries()
+	got, err := series.Progress(goal, CreateDate(2023, 6, 15))
+	require.NoError(t, err, "progress failed")
+	assert.Equal(t, CreateDate(2023, 3, 1), got.Date, "the report should end at the last reading")
+	require.Equal(t, 2, len(got.Points), "there should be no points after the last reading")
+	assert.False(t, got.OnTrack(), "the missing readings must not make the progress look better")
+	assert.InDelta(t, (3285-590)/306.0, got.DailyAllowance(), 1e-9, "the allowance should start at the last reading")
+
+	goal.BaselineFrom = CreateDate(2021, 1, 1)
+	_, err = series.Progress(goal, CreateDate(2023, 6, 15))
+	assert.EqualError(t, err, "the meter readings do not cover the baseline period from 2021-01-01 to 2023-01-01", "error message wrong")
+}
+
+func TestSeries_Progress_DegreeDays(t *testing.T) {
+	series, goal := testGoalSeries()
+	series.Heating = true
+	series.UseDegreeDays(testDegreeDays())
+	got, err := series.Progress(goal, CreateDate(2023, 3, 1))
+	require.NoError(t, err, "progress failed")
+	// the normal degree days are 12.5 per day in January and 10 per day in February, the other months are not heated
+	january, february := 31*12.5, 28*10.0
+	assert.InDelta(t, 3285*january/(january+february), got.Points[0].Target, 1e-9, "the target should follow the degree days")
+	assert.InDelta(t, 3285, got.Expected(), 1e-9, "the whole target should be expected after the heating months")
+}
+
+func ExampleGoalProgress_Render() {
+	series, goal := testGoalSeries()
+	progress, _ := series.Progress(goal, CreateDate(2023, 3, 1))
+	progress.Render(os.Stdout)
+	// Output:
+	// |    DATE    | CONSUMPTION | TARGET | DIFFERENCE |
+	// |------------|-------------|--------|------------|
+	// | 2023-02-01 |      310.00 | 279.00 |      31.00 |
+	// | 2023-03-01 |      590.00 | 531.00 |      59.00 |
+	// |------------|-------------|--------|------------|
+	// Target from 2023-01-01 to 2024-01-01: 3285.00 (10 % less than 3650.00 from 2022-01-01 to 2023-01-01)
+	// Progress: 590.00 of 531.00 used so far (behind)
+	// Daily allowance for the remaining 306 days: 8.81
+}
+
+func TestLoadFromReader_Goals(t *testing.T) {
+	got, err := LoadFromReader(strings.NewReader("goals:\n  - {baselineFrom: 2022-01-01, baselineTo: 2023-01-01, reduction: 10, validFrom: 2023-01-01, validTo: 2024-01-01}"))
+	require.NoError(t, err, "loading failed")
+	_, goal := testGoalSeries()
+	goal.Name = ""
+	assert.Equal(t, Goals{goal}, got.Goals, "goals are wrong")
+	_, err = LoadFromReader(strings.NewReader("goals:\n  - {baselineFrom: 2022-01-01, baselineTo: 2023-01-01, reduction: 110, validFrom: 2023-01-01, validTo: 2024-01-01}"))
+	assert.EqualError(t, err, "could not parse goal 0: reduction must be between 0 and 100 percent, got 110", "error message wrong")
+	_, err = LoadFromReader(strings.NewReader("goals:\n  - {baselineFrom: 2022-01-01, baselineTo: 2021-01-01, validFrom: 2023-01-01, validTo: 2024-01-01}"))
+	assert.EqualError(t, err, "could not parse goal 0: the periods must end after they start", "error message wrong")
+}
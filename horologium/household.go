@@ -0,0 +1,118 @@
+package horologium
+
+import (
+	"sort"
+	"time"
+)
+
+// Household describes the people and the flat supplied by a series from a certain date on.
+type Household struct {
+	Occupants int       // the number of people living in the household; 0 if unknown
+	Area      float64   // the (heated) living area in m²; 0 if unknown
+	ValidFrom time.Time // the date from which the household is valid; it is valid until the next household starts
+}
+
+// Households is a slice of households.
+type Households []Household
+
+// Sort sorts the households in ascending order by their ValidFrom date.
+func (h Households) Sort() {
+	sort.SliceStable(h, func(i, j int) bool {
+		return h[i].ValidFrom.Before(h[j].ValidFrom)
+	})
+}
+
+// Between returns the average number of occupants and the average area between start and end, weighted by
+// the time each household was valid. Times without a known value do not count, e.g. the area is the area of
+// the known households if the area of another household is 0. Zero is returned if no value is known.
+func (h Households) Between(start time.Time, end time.Time) (float64, float64) {
+	sorted := append(Households{}, h...)
+	sorted.Sort()
+	occupants, occupantHours := 0.0, 0.0
+	area, areaHours := 0.0, 0.0
+	for index, household := range sorted {
+		from, to := household.ValidFrom, end
+		if index+1 < len(sorted) && sorted[index+1].ValidFrom.Before(to) {
+			to = sorted[index+1].ValidFrom
+		}
+		if from.Before(start) {
+			from = start
+		}
+		hours := to.Sub(from).Hours()
+		if hours <= 0 {
+			continue
+		}
+		if household.Occupants > 0 {
+			occupants = occupants + float64(household.Occupants)*hours
+			occupantHours = occupantHours + hours
+		}
+		if household.Area > 0 {
+			area = area + household.Area*hours
+			areaHours = areaHours + hours
+		}
+	}
+	if occupantHours > 0 {
+		occupants = occupants / occupantHours
+	}
+	if areaHours > 0 {
+		area = area / areaHours
+	}
+	return occupants, area
+}
+
+// PerOccupant returns the consumption per occupant, or 0 if the number of occupants is unknown.
+func (s *Statistics) PerOccupant() float64 {
+	if s.Occupants == 0 {
+		return 0
+	}
+	return s.Consumption / s.Occupants
+}
+
+// PerArea returns the consumption per m² of living area, or 0 if the area is unknown.
+func (s *Statistics) PerArea() float64 {
+	if s.Area == 0 {
+		return 0
+	}
+	return s.Consumption / s.Area
+}
+
+// CostsPerOccupant returns the costs per occupant, or 0 if the number of occupants is unknown.
+func (s *Statistics) CostsPerOccupant() Money {
+	if s.Occupants == 0 {
+		return 0
+	}
+	return s.Costs.Multiply(1 / s.Occupants)
+}
+
+// CostsPerArea returns the costs per m² of living area, or 0 if the area is unknown.
+func (s *Statistics) CostsPerArea() Money {
+	if s.Area == 0 {
+		return 0
+	}
+	return s.Costs.Multiply(1 / s.Area)
+}
+
+// household returns the average number of occupants and area over all statistics, weighted by their length
+// (see Households.Between).
+func (m MonthlyStatistics) household() (float64, float64) {
+	occupants, occupantHours := 0.0, 0.0
+	area, areaHours := 0.0, 0.0
+	for _, stat := range m {
+		hours := stat.ValidTo.Sub(stat.ValidFrom).Hours()
+		if stat.Occupants > 0 {
+			occupants = occupants + stat.Occupants*hours
+			occupantHours = occupantHours + hours
+		}
+		if stat.Area > 0 {
+			area = area + stat.Area*hours
+			areaHours = areaHours + hours
+		}
+	}
+	if occupantHours > 0 {
+		occupants = occupants / occupantHours
+	}
+	if areaHours > 0 {
+		area = area / areaHours
+	}
+	return occupants, area
+}
@@ -0,0 +1,61 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHouseholds_Between(t *testing.T) {
+	households := Households{
+		{Occupants: 4, Area: 100, ValidFrom: CreateDate(2020, 4, 11)},
+		{Occupants: 2, ValidFrom: CreateDate(2020, 1, 1)},
+	}
+	occupants, area := households.Between(CreateDate(2020, 4, 1), CreateDate(2020, 5, 1))
+	assert.InDelta(t, (2*10+4*20)/30.0, occupants, 1e-9, "occupants should be weighted by days")
+	assert.InDelta(t, 100.0, area, 1e-9, "area should only be averaged over the days it is known")
+
+	occupants, area = households.Between(CreateDate(2019, 12, 1), CreateDate(2020, 1, 1))
+	assert.Equal(t, 0.0, occupants, "occupants before the first household are unknown")
+	assert.Equal(t, 0.0, area, "area before the first household is unknown")
+}
+
+func TestStatistics_PerOccupant(t *testing.T) {
+	stat := Statistics{Consumption: 300, Costs: NewMoney(90), Occupants: 1.5, Area: 60}
+	assert.Equal(t, 200.0, stat.PerOccupant(), "consumption per occupant is wrong")
+	assert.Equal(t, 5.0, stat.PerArea(), "consumption per m² is wrong")
+	assert.Equal(t, NewMoney(60), stat.CostsPerOccupant(), "costs per occupant are wrong")
+	assert.Equal(t, NewMoney(1.5), stat.CostsPerArea(), "costs per m² are wrong")
+	unknown := Statistics{Consumption: 300, Costs: NewMoney(90)}
+	assert.Equal(t, 0.0, unknown.PerOccupant(), "unknown occupants should give 0")
+	assert.Equal(t, Money(0), unknown.CostsPerArea(), "unknown area should give 0")
+}
+
+func TestLoadFromReader_Households(t *testing.T) {
+	got, err := LoadFromReader(strings.NewReader("households:\n  - {occupants: 2, area: 75.5, validFrom: 2020-01-01}"))
+	require.NoError(t, err, "loading failed")
+	assert.Equal(t, Households{{Occupants: 2, Area: 75.5, ValidFrom: CreateDate(2020, 1, 1)}}, got.Households, "households are wrong")
+	_, err = LoadFromReader(strings.NewReader("households:\n  - {occupants: -1, validFrom: 2020-01-01}"))
+	assert.EqualError(t, err, "could not parse household 0: occupants and area must not be negative", "error message wrong")
+}
+
+func ExampleMonthlyStatistics_RenderTable_households() {
+	series := testPaymentSeries()
+	series.AdvancePayments = nil
+	series.Households = Households{
+		{Occupants: 2, Area: 50, ValidFrom: CreateDate(2020, 1, 1)},
+		{Occupants: 4, Area: 50, ValidFrom: CreateDate(2020, 2, 16)},
+	}
+	series.MonthlyStatistics(CreateDate(2020, 1, 1), CreateDate(2020, 4, 1)).RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR | CONSUMPTION | COSTS  | PER PERSON | PER m² | COSTS PER PERSON | COSTS PER m² |
+	// |-----------|------|-------------|--------|------------|--------|------------------|--------------|
+	// | January   | 2020 |      100.00 | 105.00 |      50.00 |   2.00 |            52.50 |         2.10 |
+	// | February  |      |      100.00 | 105.00 |      33.72 |   2.00 |            35.41 |         2.10 |
+	// | March     |      |      100.00 | 105.00 |      25.00 |   2.00 |            26.25 |         2.10 |
+	// |-----------|------|-------------|--------|------------|--------|------------------|--------------|
+	// | TOTAL     |      |      300.00 | 315.00 |     100.37 |   6.00 |           105.39 |         6.30 |
+	// |-----------|------|-------------|--------|------------|--------|------------------|--------------|
+}
@@ -0,0 +1,147 @@
+package horologium
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// calendarEvent is an all-day event written by Portfolio.WriteCalendar.
+type calendarEvent struct {
+	uid         string
+	date        time.Time
+	summary     string
+	description string
+	recurrence  string // the RRULE of recurring events, empty for one-off events
+}
+
+// calendarEvents returns the reading reminders of the series (see ReadingInterval), the ends of pricing plans
+// that are not followed by another plan, and the cancellation deadline and price guarantee end of the contract
+// valid at the date.
+func (s *Series) calendarEvents(date time.Time) []calendarEvent {
+	result := make([]calendarEvent, 0)
+	if due, ok := s.NextReading(); ok {
+		result = append(result, calendarEvent{
+			uid:        "reading-" + calendarID(s.Name),
+			date:       due,
+			summary:    "Read meter: " + s.Name,
+			recurrence: readingRecurrence(*s.ReadingInterval, due),
+		})
+	}
+	for _, plan := range s.PricingPlans {
+		if plan.ValidTo == nil || s.PricingPlans.validAt(*plan.ValidTo) != nil {
+			continue
+		}
+		name := plan.Name
+		if name == "" {
+			name = "pricing plan"
+		}
+		result = append(result, calendarEvent{
+			uid:         "plan-end-" + calendarID(s.Name+"-"+name) + "-" + plan.ValidTo.Format("20060102"),
+			date:        *plan.ValidTo,
+			summary:     fmt.Sprintf("%s: %s ends", s.Name, name),
+			description: "No pricing plan is known after this date.",
+		})
+	}
+	if contract := s.Contracts.validAt(date); contract != nil {
+		id := calendarID(s.Name + "-" + contract.Provider)
+		if deadline, end := contract.Deadline(date); deadline.After(date) {
+			result = append(result, calendarEvent{
+				uid:         "cancellation-" + id + "-" + deadline.Format("20060102"),
+				date:        deadline,
+				summary:     fmt.Sprintf("%s: last day to cancel the contract with %s", s.Name, contract.Provider),
+				description: fmt.Sprintf("If cancelled by this date, the contract ends on %s.", end.Format(DateFormat)),
+			})
+		}
+		if contract.PriceGuaranteeEnd != nil {
+			result = append(result, calendarEvent{
+				uid:     "price-guarantee-" + id + "-" + contract.PriceGuaranteeEnd.Format("20060102"),
+				date:    *contract.PriceGuaranteeEnd,
+				summary: fmt.Sprintf("%s: price guarantee of %s ends", s.Name, contract.Provider),
+			})
+		}
+	}
+	return result
+}
+
+// readingRecurrence returns the RRULE of the reading reminders starting at the due date. Like ReadingInterval.Next,
+// readings due after the 28th are due on the last day of shorter months: the rule picks the last of the days from
+// the 28th up to the due day that exist in the month, since calendars skip months without the day otherwise.
+func readingRecurrence(interval ReadingInterval, due time.Time) string {
+	day := interval.Day
+	if day == 0 {
+		day = due.Day()
+	}
+	if day <= 28 {
+		return fmt.Sprintf("FREQ=MONTHLY;INTERVAL=%d;BYMONTHDAY=%d", interval.Months, day)
+	}
+	days := make([]string, 0, 4)
+	for candidate := 28; candidate <= day; candidate++ {
+		days = append(days, fmt.Sprint(candidate))
+	}
+	return fmt.Sprintf("FREQ=MONTHLY;INTERVAL=%d;BYMONTHDAY=%s;BYSETPOS=-1", interval.Months, strings.Join(days, ","))
+}
+
+// WriteCalendar writes an iCalendar file (RFC 5545) with recurring reminders to read the meters of all series
+// with a reading interval and one-off events for the ends of pricing plans as well as for the cancellation deadlines
+// and price guarantee ends of the current contracts. Every event has an alarm at 9 am.
+// The stamp is the creation time of the file; it also determines the current contracts.
+func (p Portfolio) WriteCalendar(writer io.Writer, stamp time.Time) error {
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//Horologium//Horologium//EN", "CALSCALE:GREGORIAN"}
+	for _, series := range p {
+		for _, event := range series.calendarEvents(stamp) {
+			lines = append(lines,
+				"BEGIN:VEVENT",
+				"UID:"+event.uid+"@horologium",
+				"DTSTAMP:"+stamp.UTC().Format("20060102T150405Z"),
+				"DTSTART;VALUE=DATE:"+event.date.Format("20060102"),
+				"DTEND;VALUE=DATE:"+event.date.AddDate(0, 0, 1).Format("20060102"),
+				"SUMMARY:"+escapeCalendarText(event.summary))
+			if event.description != "" {
+				lines = append(lines, "DESCRIPTION:"+escapeCalendarText(event.description))
+			}
+			if event.recurrence != "" {
+				lines = append(lines, "RRULE:"+event.recurrence)
+			}
+			lines = append(lines, "BEGIN:VALARM", "ACTION:DISPLAY", "DESCRIPTION:"+escapeCalendarText(event.summary),
+				"TRIGGER;RELATED=START:PT9H", "END:VALARM", "END:VEVENT")
+		}
+	}
+	lines = append(lines, "END:VCALENDAR")
+	for _, line := range lines {
+		if _, err := io.WriteString(writer, foldCalendarLine(line)+"\r\n"); err != nil {
+			return fmt.Errorf("could not write calendar: %v", err)
+		}
+	}
+	return nil
+}
+
+var calendarIDPattern = regexp.MustCompile("[^a-z0-9]+")
+
+// calendarID turns a name into a part of an event UID, e.g. "Power (flat)" into "power-flat".
+func calendarID(name string) string {
+	return strings.Trim(calendarIDPattern.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// escapeCalendarText escapes backslashes, semicolons, commas, and newlines in iCalendar text values.
+func escapeCalendarText(text string) string {
+	return strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\n", "\\n").Replace(text)
+}
+
+// foldCalendarLine splits lines longer than 75 octets into continuation lines without splitting UTF-8 characters.
+func foldCalendarLine(line string) string {
+	var result strings.Builder
+	length := 0
+	for _, character := range line {
+		size := len(string(character))
+		if length+size > 75 {
+			result.WriteString("\r\n ")
+			length = 1
+		}
+		result.WriteRune(character)
+		length = length + size
+	}
+	return result.String()
+}
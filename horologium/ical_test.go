@@ -0,0 +1,85 @@
+package horologium
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPortfolio_WriteCalendar(t *testing.T) {
+	planEnd := CreateDate(2024, 1, 1)
+	series := testIntervalSeries()
+	series.Name = "Water, cold"
+	series.PricingPlans = PricingPlans{{Name: "2023", ValidTo: &planEnd}}
+	buf := new(bytes.Buffer)
+	err := Portfolio{series}.WriteCalendar(buf, time.Date(2023, 6, 2, 10, 30, 0, 0, time.UTC))
+	require.NoError(t, err, "writing the calendar failed")
+	want := `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Horologium//Horologium//EN
+CALSCALE:GREGORIAN
+BEGIN:VEVENT
+UID:reading-water-cold@horologium
+DTSTAMP:20230602T103000Z
+DTSTART;VALUE=DATE:20230701
+DTEND;VALUE=DATE:20230702
+SUMMARY:Read meter: Water\, cold
+RRULE:FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=1
+BEGIN:VALARM
+ACTION:DISPLAY
+DESCRIPTION:Read meter: Water\, cold
+TRIGGER;RELATED=START:PT9H
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+UID:plan-end-water-cold-2023-20240101@horologium
+DTSTAMP:20230602T103000Z
+DTSTART;VALUE=DATE:20240101
+DTEND;VALUE=DATE:20240102
+SUMMARY:Water\, cold: 2023 ends
+DESCRIPTION:No pricing plan is known after this date.
+BEGIN:VALARM
+ACTION:DISPLAY
+DESCRIPTION:Water\, cold: 2023 ends
+TRIGGER;RELATED=START:PT9H
+END:VALARM
+END:VEVENT
+END:VCALENDAR
+`
+	assert.Equal(t, want, strings.ReplaceAll(buf.String(), "\r\n", "\n"), "calendar is wrong")
+
+	series.PricingPlans = append(series.PricingPlans, PricingPlan{Name: "2024", ValidFrom: &planEnd})
+	buf.Reset()
+	_ = Portfolio{series}.WriteCalendar(buf, time.Now())
+	assert.NotContains(t, buf.String(), "ends", "plans followed by another plan should not be in the calendar")
+}
+
+func TestReadingRecurrence(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval ReadingInterval
+		due      time.Time
+		want     string
+	}{
+		{name: "fixed day", interval: ReadingInterval{Months: 1, Day: 15}, due: CreateDate(2023, 2, 15), want: "FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=15"},
+		{name: "end of month", interval: ReadingInterval{Months: 1, Day: 31}, due: CreateDate(2023, 2, 28), want: "FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=28,29,30,31;BYSETPOS=-1"},
+		{name: "30th", interval: ReadingInterval{Months: 3, Day: 30}, due: CreateDate(2023, 4, 30), want: "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=28,29,30;BYSETPOS=-1"},
+		{name: "day of the last reading", interval: ReadingInterval{Months: 1}, due: CreateDate(2023, 1, 31), want: "FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=28,29,30,31;BYSETPOS=-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, readingRecurrence(tt.interval, tt.due), "recurrence is wrong")
+		})
+	}
+}
+
+func TestFoldCalendarLine(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("ä", 40)
+	got := strings.Split(foldCalendarLine(line), "\r\n")
+	require.Equal(t, 2, len(got), "the line should be folded once")
+	assert.LessOrEqual(t, len(got[0]), 75, "the first line should be at most 75 octets")
+	assert.Equal(t, line, got[0]+strings.TrimPrefix(got[1], " "), "unfolding should yield the original line")
+}
@@ -0,0 +1,84 @@
+package horologium
+
+import (
+	"math"
+	"time"
+)
+
+// Interpolation estimates the count of a meter between two consecutive meter readings and thus determines
+// how the consumption between the readings is distributed in time.
+type Interpolation interface {
+	// Interpolate returns the estimated count at the date, which is after the previous and before the next reading.
+	Interpolate(previous MeterReading, next MeterReading, date time.Time) float64
+}
+
+// LinearInterpolation distributes the consumption evenly over all days between two readings. It is the default.
+type LinearInterpolation struct{}
+
+// Interpolate implements Interpolation.
+func (LinearInterpolation) Interpolate(previous MeterReading, next MeterReading, date time.Time) float64 {
+	differenceDays := math.Round(next.Date.Sub(previous.Date).Hours() / 24)
+	slope := (next.Count - previous.Count) / differenceDays
+	xValue := math.Round(date.Sub(previous.Date).Hours() / 24)
+	return slope*xValue + previous.Count
+}
+
+// StepInterpolation keeps the count of the previous reading until the next reading, i.e. the consumption between
+// two readings is attributed to the date of the later reading. This suits meters that are read when billed.
+type StepInterpolation struct{}
+
+// Interpolate implements Interpolation.
+func (StepInterpolation) Interpolate(previous MeterReading, _ MeterReading, _ time.Time) float64 {
+	return previous.Count
+}
+
+// LoadProfile assigns a relative weight to every day, e.g. higher weights in winter for heating.
+type LoadProfile interface {
+	// DailyWeight returns the weight of the day, which must not be negative.
+	DailyWeight(date time.Time) float64
+}
+
+// ProfileInterpolation distributes the consumption between two readings according to a load profile:
+// the consumption of a day is proportional to its weight.
+type ProfileInterpolation struct {
+	Profile LoadProfile
+}
+
+// Interpolate implements Interpolation. If the weights of all days between the readings are zero,
+// the consumption is distributed linearly.
+func (p ProfileInterpolation) Interpolate(previous MeterReading, next MeterReading, date time.Time) float64 {
+	total := p.weightBetween(previous.Date, next.Date)
+	if total == 0 {
+		return LinearInterpolation{}.Interpolate(previous, next, date)
+	}
+	return previous.Count + (next.Count-previous.Count)*p.weightBetween(previous.Date, date)/total
+}
+
+func (p ProfileInterpolation) weightBetween(start time.Time, end time.Time) float64 {
+	result := 0.0
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		result = result + p.Profile.DailyWeight(day)
+	}
+	return result
+}
+
+// MonthlyProfile is a load profile given by one weight per month (January first). The weights are relative
+// to each other and describe the consumption of the whole month; the weight of a month is distributed evenly
+// over its days.
+type MonthlyProfile [12]float64
+
+// DailyWeight implements LoadProfile.
+func (m MonthlyProfile) DailyWeight(date time.Time) float64 {
+	days := CreateDate(date.Year(), int(date.Month()), 1).AddDate(0, 1, -1).Day()
+	return m[date.Month()-1] / float64(days)
+}
+
+// H0Profile approximates the BDEW standard load profile H0 for households by its dynamization function,
+// which models the seasonal variation of the daily power consumption (higher in winter, lower in summer).
+type H0Profile struct{}
+
+// DailyWeight implements LoadProfile.
+func (H0Profile) DailyWeight(date time.Time) float64 {
+	t := float64(date.YearDay())
+	return -3.92e-10*math.Pow(t, 4) + 3.2e-7*math.Pow(t, 3) - 7.02e-5*math.Pow(t, 2) + 2.1e-3*t + 1.24
+}
@@ -0,0 +1,84 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func testInterpolationReadings() MeterReadings {
+	return MeterReadings{{Date: CreateDate(2021, 1, 1), Count: 0}, {Date: CreateDate(2021, 3, 1), Count: 590}}
+}
+
+func TestMeterReadings_InterpolatedConsumption(t *testing.T) {
+	heating := MonthlyProfile{3, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+	tests := []struct {
+		name          string
+		interpolation Interpolation
+		want          float64
+	}{
+		{name: "default", interpolation: nil, want: 310},
+		{name: "linear", interpolation: LinearInterpolation{}, want: 310},
+		{name: "step", interpolation: StepInterpolation{}, want: 0},
+		{name: "monthly profile", interpolation: ProfileInterpolation{Profile: heating}, want: 442.5},
+		{name: "zero weights", interpolation: ProfileInterpolation{Profile: MonthlyProfile{}}, want: 310},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readings := testInterpolationReadings()
+			got := readings.InterpolatedConsumption(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1), tt.interpolation)
+			assert.InDelta(t, tt.want, got, 1e-9, "consumption in January is wrong")
+			total := readings.InterpolatedConsumption(CreateDate(2021, 1, 1), CreateDate(2021, 3, 1), tt.interpolation)
+			assert.InDelta(t, 590, total, 1e-9, "the consumption between the readings must not change")
+		})
+	}
+}
+
+func TestH0Profile_DailyWeight(t *testing.T) {
+	profile := H0Profile{}
+	winter := profile.DailyWeight(CreateDate(2021, 1, 15))
+	summer := profile.DailyWeight(CreateDate(2021, 7, 15))
+	assert.Greater(t, winter, 1.15, "winter days should weigh more than average")
+	assert.Less(t, summer, 0.9, "summer days should weigh less than average")
+}
+
+func TestSeries_Interpolation(t *testing.T) {
+	series := Series{
+		PricingPlans:  PricingPlans{{UnitPrice: NewMoney(0.3)}},
+		MeterReadings: testInterpolationReadings(),
+		Interpolation: StepInterpolation{},
+	}
+	stats := series.MonthlyStatistics(CreateDate(2021, 1, 1), CreateDate(2021, 3, 1))
+	require.Equal(t, 2, len(stats), "there should be two months")
+	assert.Equal(t, 0.0, stats[0].Consumption, "with steps, January should have no consumption")
+	assert.Equal(t, 590.0, stats[1].Consumption, "with steps, February should have all the consumption")
+}
+
+func TestLoadFromReader_Interpolation(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   Interpolation
+		errMsg string
+	}{
+		{name: "step", input: "{method: step}", want: StepInterpolation{}},
+		{name: "h0", input: "{method: profile, profile: h0}", want: ProfileInterpolation{Profile: H0Profile{}}},
+		{name: "weights", input: "{method: profile, weights: [2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2]}",
+			want: ProfileInterpolation{Profile: MonthlyProfile{2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2}}},
+		{name: "unknown method", input: "{method: spline}", errMsg: "could not parse interpolation: unknown method \"spline\", expected linear, step, profile, or degreeDays"},
+		{name: "unknown profile", input: "{method: profile, profile: g0}", errMsg: "could not parse interpolation: unknown profile \"g0\", expected h0 or monthly weights"},
+		{name: "missing weights", input: "{method: profile, weights: [1, 2]}", errMsg: "could not parse interpolation: expected 12 monthly weights, got 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadFromReader(strings.NewReader("interpolation: " + tt.input))
+			if tt.errMsg != "" {
+				assert.EqualError(t, err, tt.errMsg, "error message wrong")
+				return
+			}
+			require.NoError(t, err, "loading failed")
+			assert.Equal(t, tt.want, got.Interpolation, "interpolation is wrong")
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package horologium
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// Meter is a physical meter of a series. Meters have to be re-calibrated or replaced when their
+// calibration expires (e.g. the Eichfrist in Germany).
+type Meter struct {
+	SerialNumber       string
+	Installed          time.Time  // the date the meter was installed; it counts the readings until the next meter is installed
+	CalibrationExpires *time.Time // the first day on which the calibration is not valid anymore, nil if unknown
+}
+
+// Meters is a slice of meters.
+type Meters []Meter
+
+// Sort sorts the meters in ascending order by their installation date.
+func (m Meters) Sort() {
+	sort.SliceStable(m, func(i, j int) bool {
+		return m[i].Installed.Before(m[j].Installed)
+	})
+}
+
+// meterAt returns the meter installed last on or before the date, or nil if there is none.
+func (m Meters) meterAt(date time.Time) *Meter {
+	var result *Meter
+	for index := range m {
+		if !m[index].Installed.After(date) && (result == nil || !m[index].Installed.Before(result.Installed)) {
+			result = &m[index]
+		}
+	}
+	return result
+}
+
+// CalibrationWarning reports a meter reading taken on a meter whose calibration had already expired.
+type CalibrationWarning struct {
+	Meter   Meter
+	Reading MeterReading
+}
+
+// String returns a description of the warning.
+func (c CalibrationWarning) String() string {
+	return fmt.Sprintf("the reading of %s was taken on meter %s, whose calibration expired on %s",
+		c.Reading.Date.Format(DateFormat), c.Meter.SerialNumber, c.Meter.CalibrationExpires.Format(DateFormat))
+}
+
+// CalibrationWarnings returns a warning for every meter reading taken on or after the calibration of its meter expired.
+func (s *Series) CalibrationWarnings() []CalibrationWarning {
+	result := make([]CalibrationWarning, 0)
+	for _, reading := range s.MeterReadings {
+		meter := s.Meters.meterAt(reading.Date)
+		if meter != nil && meter.CalibrationExpires != nil && !reading.Date.Before(*meter.CalibrationExpires) {
+			result = append(result, CalibrationWarning{Meter: *meter, Reading: reading})
+		}
+	}
+	return result
+}
+
+// MeterStatus is the calibration status of the current meter of a series.
+type MeterStatus struct {
+	Name  string // the name of the series
+	Meter Meter
+}
+
+// MeterStatuses is a slice of meter statuses.
+type MeterStatuses []MeterStatus
+
+// ExpiringMeters returns the current meter of every series whose calibration expires within the given number of days
+// after the date or has already expired, sorted by the expiry date.
+func (p Portfolio) ExpiringMeters(date time.Time, days int) MeterStatuses {
+	result := make(MeterStatuses, 0, len(p))
+	limit := date.AddDate(0, 0, days)
+	for _, series := range p {
+		meter := series.Meters.meterAt(date)
+		if meter != nil && meter.CalibrationExpires != nil && !meter.CalibrationExpires.After(limit) {
+			result = append(result, MeterStatus{Name: series.Name, Meter: *meter})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Meter.CalibrationExpires.Before(*result[j].Meter.CalibrationExpires)
+	})
+	return result
+}
+
+// RenderTable renders the meter statuses as table with the status of the calibration relative to the given date.
+func (m MeterStatuses) RenderTable(writer io.Writer, date time.Time) {
+	columns := []tableColumn{{header: "SERIES", left: true}, {header: "SERIAL NO.", left: true}, {header: "INSTALLED", left: true},
+		{header: "CALIBRATION EXPIRES", left: true}, {header: "STATUS", left: true}}
+	rows := make([][]string, 0, len(m))
+	for _, status := range m {
+		days := int(math.Round(status.Meter.CalibrationExpires.Sub(date).Hours() / 24))
+		state := fmt.Sprintf("expires in %d day(s)", days)
+		if days <= 0 {
+			state = "expired"
+		}
+		rows = append(rows, []string{status.Name, status.Meter.SerialNumber, status.Meter.Installed.Format(DateFormat),
+			status.Meter.CalibrationExpires.Format(DateFormat), state})
+	}
+	renderTable(writer, columns, rows, nil)
+}
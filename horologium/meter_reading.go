@@ -2,21 +2,72 @@ package horologium
 
 import (
 	"fmt"
-	"math"
+	"io"
 	"sort"
 	"time"
 )
 
 // MeterReading represents the counter on a certain meter at a certain date.
 type MeterReading struct {
-	Count float64   // The count showing on the meter.
-	Date  time.Time // The date the meter showed the Count. The time part of the date should always be 0:00.
+	Count  float64       // The count showing on the meter.
+	Date   time.Time     // The date the meter showed the Count. The time part of the date should always be 0:00.
+	Source ReadingSource // Where the reading comes from, e.g. an estimate of the provider.
+	Note   string        // A free-text note, e.g. why the reading was estimated.
+}
+
+// ReadingSource describes the origin and quality of a meter reading.
+type ReadingSource int
+
+const (
+	// ReadingActual is a reading of the meter taken by the household. It is the default.
+	ReadingActual ReadingSource = iota
+	// ReadingEstimated is an estimated count, e.g. by the provider when the meter could not be read.
+	ReadingEstimated
+	// ReadingProvider is a reading taken by the provider, e.g. for the annual bill.
+	ReadingProvider
+	// ReadingImported is a reading imported from another system, e.g. a smart meter.
+	ReadingImported
+)
+
+// String returns the name of the source as used in series files, e.g. estimated.
+func (r ReadingSource) String() string {
+	switch r {
+	case ReadingEstimated:
+		return "estimated"
+	case ReadingProvider:
+		return "provider"
+	case ReadingImported:
+		return "imported"
+	default:
+		return "actual"
+	}
+}
+
+// withoutEstimates returns the readings without the estimated readings between the first and the last
+// reading that is not estimated. Estimates before or after all other readings are kept since nothing replaces them.
+func (m MeterReadings) withoutEstimates() MeterReadings {
+	first, last := -1, -1
+	for index, reading := range m {
+		if reading.Source != ReadingEstimated {
+			if first < 0 {
+				first = index
+			}
+			last = index
+		}
+	}
+	result := make(MeterReadings, 0, len(m))
+	for index, reading := range m {
+		if reading.Source != ReadingEstimated || index < first || index > last {
+			result = append(result, reading)
+		}
+	}
+	return result
 }
 
 // A slice of meter readings.
 type MeterReadings []MeterReading
 
-func (m MeterReadings) interpolateValueAtDate(date time.Time) float64 {
+func (m MeterReadings) interpolateValueAtDate(date time.Time, interpolation Interpolation) float64 {
 	firstReading := m.lastReadingBefore(date)
 	lastReading := m.firstReadingAfter(date)
 	if firstReading == nil {
@@ -28,10 +79,7 @@ func (m MeterReadings) interpolateValueAtDate(date time.Time) float64 {
 	if date == lastReading.Date || date.After(lastReading.Date) {
 		return lastReading.Count
 	}
-	differenceDays := math.Round(lastReading.Date.Sub(firstReading.Date).Hours() / 24)
-	slope := (lastReading.Count - firstReading.Count) / differenceDays
-	xValue := math.Round(date.Sub(firstReading.Date).Hours() / 24)
-	return slope*xValue + firstReading.Count
+	return interpolation.Interpolate(*firstReading, lastReading, date)
 }
 
 func (m MeterReadings) lastReadingBefore(date time.Time) *MeterReading {
@@ -62,8 +110,17 @@ func (m MeterReadings) firstReadingAfter(date time.Time) MeterReading {
 //
 // Between two consecutive meter readings the consumption is assumed to be linear.
 func (m MeterReadings) Consumption(start time.Time, end time.Time) float64 {
-	valueStart := m.interpolateValueAtDate(start)
-	valueEnd := m.interpolateValueAtDate(end)
+	return m.InterpolatedConsumption(start, end, LinearInterpolation{})
+}
+
+// InterpolatedConsumption computes the consumption between start and end like Consumption, but estimates
+// the counts between two consecutive meter readings with the given interpolation (linear if nil).
+func (m MeterReadings) InterpolatedConsumption(start time.Time, end time.Time, interpolation Interpolation) float64 {
+	if interpolation == nil {
+		interpolation = LinearInterpolation{}
+	}
+	valueStart := m.interpolateValueAtDate(start, interpolation)
+	valueEnd := m.interpolateValueAtDate(end, interpolation)
 	return valueEnd - valueStart
 }
 
@@ -75,6 +132,16 @@ func (m MeterReadings) Sort() {
 	})
 }
 
+// RenderTable renders the meter readings as table with their source and note in the order of the slice.
+func (m MeterReadings) RenderTable(writer io.Writer) {
+	columns := []tableColumn{{header: "DATE", left: true}, {header: "COUNT"}, {header: "SOURCE", left: true}, {header: "NOTE", left: true}}
+	rows := make([][]string, 0, len(m))
+	for _, reading := range m {
+		rows = append(rows, []string{reading.Date.Format(DateFormat), formatNumber(reading.Count), reading.Source.String(), reading.Note})
+	}
+	renderTable(writer, columns, rows, nil)
+}
+
 // Convenience method to create a date based on a date with time 0:00.
 //
 // Do not enter trailing zeros for months and days because then Go will treat the numbers octal.
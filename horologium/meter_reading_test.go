@@ -3,6 +3,7 @@ package horologium
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"os"
 	"testing"
 	"time"
 )
@@ -27,7 +28,7 @@ func TestInterpolateValueAtDate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.date, func(t *testing.T) {
 			date, _ := time.Parse(DateFormat, tt.date)
-			got := readings.interpolateValueAtDate(date)
+			got := readings.interpolateValueAtDate(date, LinearInterpolation{})
 			assert.Equal(t, tt.want, got, "interpolated value is wrong")
 		})
 	}
@@ -113,6 +114,46 @@ func ExampleMeterReadings_Sort() {
 	// 2019-04-05,2019-04-10,2019-04-15
 }
 
+func TestMeterReadings_withoutEstimates(t *testing.T) {
+	readings := MeterReadings{
+		{Date: CreateDate(2019, 1, 1), Count: 100, Source: ReadingEstimated},
+		{Date: CreateDate(2019, 2, 1), Count: 200},
+		{Date: CreateDate(2019, 3, 1), Count: 400, Source: ReadingEstimated},
+		{Date: CreateDate(2019, 4, 1), Count: 500, Source: ReadingProvider},
+		{Date: CreateDate(2019, 5, 1), Count: 600, Source: ReadingEstimated},
+	}
+	want := MeterReadings{readings[0], readings[1], readings[3], readings[4]}
+	assert.Equal(t, want, readings.withoutEstimates(), "only estimates between other readings must be removed")
+	estimates := MeterReadings{readings[0], readings[2]}
+	assert.Equal(t, estimates, estimates.withoutEstimates(), "estimates must be kept if there are no other readings")
+}
+
+func TestSeries_IgnoreEstimates(t *testing.T) {
+	series := Series{MeterReadings: MeterReadings{
+		{Date: CreateDate(2019, 1, 1), Count: 100},
+		{Date: CreateDate(2019, 1, 11), Count: 400, Source: ReadingEstimated},
+		{Date: CreateDate(2019, 1, 21), Count: 300},
+	}}
+	assert.Equal(t, 300.0, series.meterConsumption(CreateDate(2019, 1, 1), CreateDate(2019, 1, 11)), "estimate should be used by default")
+	series.IgnoreEstimates = true
+	assert.Equal(t, 100.0, series.meterConsumption(CreateDate(2019, 1, 1), CreateDate(2019, 1, 11)), "estimate should be ignored")
+}
+
+func ExampleMeterReadings_RenderTable() {
+	readings := MeterReadings{
+		{Date: CreateDate(2019, 1, 1), Count: 1200.5},
+		{Date: CreateDate(2019, 2, 1), Count: 1350, Source: ReadingEstimated, Note: "nobody home"},
+		{Date: CreateDate(2019, 3, 1), Count: 1512.25, Source: ReadingProvider},
+	}
+	readings.RenderTable(os.Stdout)
+	// Output: |    DATE    |  COUNT  |  SOURCE   |    NOTE     |
+	// |------------|---------|-----------|-------------|
+	// | 2019-01-01 |  1200.5 | actual    |             |
+	// | 2019-02-01 |    1350 | estimated | nobody home |
+	// | 2019-03-01 | 1512.25 | provider  |             |
+	// |------------|---------|-----------|-------------|
+}
+
 func ExampleCreateDate() {
 	date := CreateDate(2019, 10, 12)
 	formatted := date.Format(time.RFC1123)
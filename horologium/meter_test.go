@@ -0,0 +1,56 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testMeterSeries() *Series {
+	oldExpiry := CreateDate(2023, 1, 1)
+	newExpiry := CreateDate(2031, 1, 1)
+	return &Series{
+		Name: "Water",
+		Meters: Meters{
+			{SerialNumber: "B-2", Installed: CreateDate(2023, 2, 15), CalibrationExpires: &newExpiry},
+			{SerialNumber: "A-1", Installed: CreateDate(2017, 1, 1), CalibrationExpires: &oldExpiry},
+		},
+		MeterReadings: MeterReadings{
+			{Date: CreateDate(2022, 12, 1), Count: 100},
+			{Date: CreateDate(2023, 1, 1), Count: 110},
+			{Date: CreateDate(2023, 2, 1), Count: 120},
+			{Date: CreateDate(2023, 3, 1), Count: 5},
+		},
+	}
+}
+
+func TestSeries_CalibrationWarnings(t *testing.T) {
+	got := testMeterSeries().CalibrationWarnings()
+	require.Equal(t, 2, len(got), "readings on the expired meter should be reported")
+	assert.Equal(t, CreateDate(2023, 1, 1), got[0].Reading.Date, "the reading on the expiry date should be reported")
+	assert.Equal(t, "A-1", got[1].Meter.SerialNumber, "meter of the warning is wrong")
+	assert.Equal(t, "the reading of 2023-02-01 was taken on meter A-1, whose calibration expired on 2023-01-01", got[1].String(), "warning is wrong")
+}
+
+func ExampleMeterStatuses_RenderTable() {
+	gas := testMeterSeries()
+	gas.Name = "Gas"
+	gas.Meters = gas.Meters[1:]
+	Portfolio{testMeterSeries(), gas}.ExpiringMeters(CreateDate(2030, 7, 1), 365).RenderTable(os.Stdout, CreateDate(2030, 7, 1))
+	// Output:
+	// | SERIES | SERIAL NO. | INSTALLED  | CALIBRATION EXPIRES |        STATUS         |
+	// |--------|------------|------------|---------------------|-----------------------|
+	// | Gas    | A-1        | 2017-01-01 | 2023-01-01          | expired               |
+	// | Water  | B-2        | 2023-02-15 | 2031-01-01          | expires in 184 day(s) |
+	// |--------|------------|------------|---------------------|-----------------------|
+}
+
+func TestLoadFromReader_Meters(t *testing.T) {
+	got, err := LoadFromReader(strings.NewReader("meters:\n  - {serialNumber: A-1, installed: 2017-01-01}"))
+	require.NoError(t, err, "loading failed")
+	assert.Equal(t, Meters{{SerialNumber: "A-1", Installed: CreateDate(2017, 1, 1)}}, got.Meters, "meters are wrong")
+	_, err = LoadFromReader(strings.NewReader("meters:\n  - {serialNumber: A-1, installed: 2017-01-01, calibrationExpires: soon}"))
+	assert.EqualError(t, err, "could not parse meter 0: could not parse calibrationExpires date: parsing time \"soon\" as \"2006-01-02\": cannot parse \"soon\" as \"2006\"", "error message wrong")
+}
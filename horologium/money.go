@@ -0,0 +1,233 @@
+package horologium
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact decimal amount of money, stored as an integral number of millionths
+// of the currency unit. Sums and differences of amounts can be computed with the usual
+// operators without rounding errors; multiplications with consumptions are rounded to
+// the nearest millionth (see Multiply).
+//
+// Money implements fmt.Formatter: the verbs %f, %F, %v, and %s print the exact decimal value,
+// e.g. fmt.Sprintf("%.2f €", money). Values are rounded half up to the requested precision.
+type Money int64
+
+// moneyScale is the number of millionths in one currency unit.
+const moneyScale = 1000000
+
+// moneyDecimals is the maximum number of decimals a Money value can represent.
+const moneyDecimals = 6
+
+// NewMoney converts a floating point number to Money by rounding it to the nearest millionth.
+func NewMoney(value float64) Money {
+	return Money(math.Round(value * moneyScale))
+}
+
+// ParseMoney parses a decimal number like "-12.3456" without any floating point arithmetic.
+// Numbers with more than six decimals are rejected.
+func ParseMoney(text string) (Money, error) {
+	trimmed := strings.TrimSpace(text)
+	negative := strings.HasPrefix(trimmed, "-")
+	trimmed = strings.TrimPrefix(strings.TrimPrefix(trimmed, "-"), "+")
+	parts := strings.SplitN(trimmed, ".", 2)
+	if parts[0] == "" && (len(parts) == 1 || parts[1] == "") {
+		return 0, fmt.Errorf("\"%s\" is not a decimal number", text)
+	}
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = parts[1]
+	}
+	if len(fraction) > moneyDecimals {
+		return 0, fmt.Errorf("\"%s\" has more than %d decimals", text, moneyDecimals)
+	}
+	digits := parts[0] + fraction + strings.Repeat("0", moneyDecimals-len(fraction))
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || strings.ContainsAny(digits, "+-") {
+		return 0, fmt.Errorf("\"%s\" is not a decimal number", text)
+	}
+	if negative {
+		value = -value
+	}
+	return Money(value), nil
+}
+
+// Float returns the amount as floating point number, e.g. for computing ratios.
+func (m Money) Float() float64 {
+	return float64(m) / moneyScale
+}
+
+// Multiply multiplies the amount with the factor, e.g. a unit price with a consumption.
+// The result is rounded to the nearest millionth.
+func (m Money) Multiply(factor float64) Money {
+	return Money(math.Round(float64(m) * factor))
+}
+
+// Round rounds the amount to the given number of decimals using the rounding mode.
+func (m Money) Round(decimals int, mode RoundingMode) Money {
+	if decimals >= moneyDecimals {
+		return m
+	}
+	if decimals < 0 {
+		decimals = 0
+	}
+	step := Money(math.Pow10(moneyDecimals - decimals))
+	quotient := m / step
+	remainder := m % step
+	if remainder < 0 {
+		remainder = -remainder
+	}
+	roundAway := remainder*2 > step || (remainder*2 == step && (mode == RoundHalfUp || quotient%2 != 0))
+	if roundAway && m < 0 {
+		quotient = quotient - 1
+	} else if roundAway {
+		quotient = quotient + 1
+	}
+	return quotient * step
+}
+
+// String returns the exact decimal representation of the amount without trailing zeros, e.g. "12.5".
+func (m Money) String() string {
+	text := m.format(moneyDecimals)
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+	return text
+}
+
+func (m Money) format(decimals int) string {
+	rounded := m.Round(decimals, RoundHalfUp)
+	sign := ""
+	if rounded < 0 {
+		sign = "-"
+		rounded = -rounded
+	}
+	units := int64(rounded) / moneyScale
+	fraction := fmt.Sprintf("%06d", int64(rounded)%moneyScale)[:decimals]
+	if decimals == 0 {
+		return fmt.Sprintf("%s%d", sign, units)
+	}
+	return fmt.Sprintf("%s%d.%s", sign, units, fraction)
+}
+
+// Format implements fmt.Formatter. It supports the verbs f, F, v, and s together with
+// the precision, width, and the flags '+', '-', and '0'. The verbs e, E, g, and G format the
+// amount as float, so they may lose precision for very large amounts.
+func (m Money) Format(state fmt.State, verb rune) {
+	var text string
+	precision, hasPrecision := state.Precision()
+	switch verb {
+	case 'f', 'F':
+		if !hasPrecision {
+			precision = 6
+		}
+		text = m.format(int(math.Min(float64(precision), moneyDecimals)))
+		if precision > moneyDecimals {
+			text = text + strings.Repeat("0", precision-moneyDecimals)
+		}
+	case 'v', 's':
+		text = m.String()
+		if hasPrecision {
+			text = m.format(int(math.Min(float64(precision), moneyDecimals)))
+		}
+	case 'e', 'E', 'g', 'G':
+		spec := "%"
+		for _, flag := range "+-# 0" {
+			if state.Flag(int(flag)) {
+				spec = spec + string(flag)
+			}
+		}
+		if width, hasWidth := state.Width(); hasWidth {
+			spec = spec + strconv.Itoa(width)
+		}
+		if hasPrecision {
+			spec = spec + "." + strconv.Itoa(precision)
+		}
+		_, _ = fmt.Fprintf(state, spec+string(verb), m.Float())
+		return
+	default:
+		_, _ = fmt.Fprintf(state, "%%!%c(horologium.Money=%s)", verb, m.String())
+		return
+	}
+	if state.Flag('+') && m >= 0 {
+		text = "+" + text
+	}
+	width, hasWidth := state.Width()
+	if hasWidth && len(text) < width {
+		padding := width - len(text)
+		switch {
+		case state.Flag('-'):
+			text = text + strings.Repeat(" ", padding)
+		case state.Flag('0'):
+			sign := ""
+			if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+				sign, text = text[:1], text[1:]
+			}
+			text = sign + strings.Repeat("0", padding) + text
+		default:
+			text = strings.Repeat(" ", padding) + text
+		}
+	}
+	_, _ = fmt.Fprint(state, text)
+}
+
+// RoundingMode determines how amounts exactly between two values are rounded.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero, e.g. 0.125 becomes 0.13.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds halves to the nearest even digit (banker's rounding), e.g. 0.125 becomes 0.12.
+	RoundHalfEven
+)
+
+// RoundingStage determines at which point of the cost calculation amounts are rounded.
+type RoundingStage int
+
+const (
+	// RoundNever does not round any amounts (apart from the precision of Money).
+	RoundNever RoundingStage = iota
+	// RoundFinal only rounds the final amount of a bill, e.g. the total of the monthly statistics.
+	RoundFinal
+	// RoundMonthly rounds the costs of every month; the final amount is the sum of the rounded months.
+	RoundMonthly
+	// RoundLineItem rounds every line item, i.e. the unit costs and the base price of every pricing plan,
+	// every one-time charge and every relief; all sums of line items are thus rounded as well.
+	RoundLineItem
+)
+
+// RoundingRule describes how a provider rounds the amounts of a bill.
+// The zero value does not round at all.
+type RoundingRule struct {
+	Stage    RoundingStage // the stage at which amounts are rounded
+	Mode     RoundingMode  // how halves are rounded
+	Decimals int           // the number of decimals to round to, usually 2
+}
+
+// round rounds the amount if the rule rounds at the given stage or a finer stage.
+// Rounding an already rounded amount does not change it; thus, rounding the final amount
+// of a bill whose line items have already been rounded is harmless.
+func (r RoundingRule) round(stage RoundingStage, amount Money) Money {
+	if r.Stage == RoundNever || r.Stage < stage {
+		return amount
+	}
+	return amount.Round(r.Decimals, r.Mode)
+}
+
+// UnmarshalYAML parses a yaml number exactly, i.e. without converting it to a floating point number first.
+func (m *Money) UnmarshalYAML(data []byte) error {
+	text := strings.Trim(strings.TrimSpace(string(data)), "\"'")
+	value, err := ParseMoney(text)
+	if err != nil {
+		number, floatErr := strconv.ParseFloat(text, 64)
+		if floatErr != nil {
+			return err
+		}
+		value = NewMoney(number)
+	}
+	*m = value
+	return nil
+}
@@ -0,0 +1,140 @@
+package horologium
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    Money
+		wantErr string
+	}{
+		{text: "26.32", want: 26320000},
+		{text: "-0.05", want: -50000},
+		{text: "+3", want: 3000000},
+		{text: ".5", want: 500000},
+		{text: "0.000001", want: 1},
+		{text: "0.0000001", wantErr: "\"0.0000001\" has more than 6 decimals"},
+		{text: "1.2.3", wantErr: "\"1.2.3\" is not a decimal number"},
+		{text: "--1", wantErr: "\"--1\" is not a decimal number"},
+		{text: "", wantErr: "\"\" is not a decimal number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseMoney(tt.text)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr, "error message wrong")
+				return
+			}
+			require.NoError(t, err, "parsing failed")
+			assert.Equal(t, tt.want, got, "parsed amount is wrong")
+		})
+	}
+}
+
+func TestMoney_Round(t *testing.T) {
+	tests := []struct {
+		amount float64
+		mode   RoundingMode
+		want   float64
+	}{
+		{amount: 0.125, mode: RoundHalfUp, want: 0.13},
+		{amount: 0.125, mode: RoundHalfEven, want: 0.12},
+		{amount: 0.135, mode: RoundHalfEven, want: 0.14},
+		{amount: -0.125, mode: RoundHalfUp, want: -0.13},
+		{amount: -0.125, mode: RoundHalfEven, want: -0.12},
+		{amount: -0.135, mode: RoundHalfEven, want: -0.14},
+		{amount: 0.124999, mode: RoundHalfUp, want: 0.12},
+		{amount: -0.125001, mode: RoundHalfEven, want: -0.13},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v %d", tt.amount, tt.mode), func(t *testing.T) {
+			assert.Equal(t, NewMoney(tt.want), NewMoney(tt.amount).Round(2, tt.mode), "rounded amount is wrong")
+		})
+	}
+	assert.Equal(t, NewMoney(13), NewMoney(12.5).Round(0, RoundHalfUp), "rounding to integers is wrong")
+	assert.Equal(t, NewMoney(0.1234567), NewMoney(0.1234567).Round(8, RoundHalfUp), "rounding beyond the precision must not change the amount")
+}
+
+func ExampleMoney_Format() {
+	price, _ := ParseMoney("0.1")
+	total := price.Multiply(3)
+	fmt.Printf("%v | %.2f | %8.3f | %-6.1f| %+.1f | %06.2f\n", total, total, total, total, total, -total)
+	// Output:
+	// 0.3 | 0.30 |    0.300 | 0.3   | +0.3 | -00.30
+}
+
+func TestMoney_Format_Float(t *testing.T) {
+	amount := NewMoney(1234.5)
+	assert.Equal(t, "1234.5", fmt.Sprintf("%g", amount), "%g is wrong")
+	assert.Equal(t, "1.23e+03", fmt.Sprintf("%.3g", amount), "%.3g is wrong")
+	assert.Equal(t, "1.234500E+03", fmt.Sprintf("%E", amount), "%E is wrong")
+	assert.Equal(t, "  +1.23e+03", fmt.Sprintf("%+11.2e", amount), "%+11.2e is wrong")
+	assert.Equal(t, "%!x(horologium.Money=1234.5)", fmt.Sprintf("%x", amount), "unsupported verbs should be reported")
+}
+
+func testRoundingSeries(rule RoundingRule) *Series {
+	return &Series{
+		PricingPlans:  PricingPlans{{BasePrice: NewMoney(0.9985), UnitPrice: NewMoney(0.001)}},
+		MeterReadings: MeterReadings{{Date: CreateDate(2021, 1, 1), Count: 0}, {Date: CreateDate(2021, 2, 1), Count: 5}, {Date: CreateDate(2021, 3, 1), Count: 10}},
+		Rounding:      rule,
+	}
+}
+
+func TestSeries_CostsAndConsumption_Rounding(t *testing.T) {
+	tests := []struct {
+		name string
+		rule RoundingRule
+		want Money
+	}{
+		{name: "never", rule: RoundingRule{}, want: NewMoney(2.007)},
+		{name: "final", rule: RoundingRule{Stage: RoundFinal, Decimals: 2}, want: NewMoney(2.01)},
+		{name: "monthly", rule: RoundingRule{Stage: RoundMonthly, Decimals: 2}, want: NewMoney(2)},
+		{name: "line item", rule: RoundingRule{Stage: RoundLineItem, Decimals: 2}, want: NewMoney(2.01)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := testRoundingSeries(tt.rule)
+			got, _ := series.CostsAndConsumption(CreateDate(2021, 1, 1), CreateDate(2021, 3, 1))
+			assert.Equal(t, tt.want, got, "costs are wrong")
+		})
+	}
+}
+
+func TestSeries_MonthlyStatistics_Rounding(t *testing.T) {
+	series := testRoundingSeries(RoundingRule{Stage: RoundFinal, Decimals: 2})
+	got := series.MonthlyStatistics(CreateDate(2021, 1, 1), CreateDate(2021, 3, 1))
+	assert.Equal(t, NewMoney(1.0035), got[0].Costs, "months must not be rounded with the final stage")
+	_, total := got.Total()
+	assert.Equal(t, NewMoney(2.01), total, "total must be rounded with the final stage")
+	series.Rounding.Stage = RoundMonthly
+	got = series.MonthlyStatistics(CreateDate(2021, 1, 1), CreateDate(2021, 3, 1))
+	assert.Equal(t, NewMoney(1), got[0].Costs, "months must be rounded with the monthly stage")
+	series.Rounding.Stage = RoundLineItem
+	got = series.MonthlyStatistics(CreateDate(2021, 1, 1), CreateDate(2021, 3, 1))
+	assert.Equal(t, NewMoney(1.01), got[0].Costs, "line items of every month must be rounded")
+	series.Rounding.Mode = RoundHalfEven
+	got = series.MonthlyStatistics(CreateDate(2021, 1, 1), CreateDate(2021, 3, 1))
+	assert.Equal(t, NewMoney(1), got[0].Costs, "line items must be rounded half even")
+}
+
+func TestLoadFromReader_Rounding(t *testing.T) {
+	file := `plans:
+  - {name: "2021", basePrice: 0.9985, unitPrice: 0.1234}
+rounding: {stage: lineItem, mode: halfEven}`
+	got, err := LoadFromReader(strings.NewReader(file))
+	require.NoError(t, err, "loading failed")
+	assert.Equal(t, RoundingRule{Stage: RoundLineItem, Mode: RoundHalfEven, Decimals: 2}, got.Rounding, "rounding rule is wrong")
+	assert.Equal(t, Money(998500), got.PricingPlans[0].BasePrice, "prices must be parsed exactly")
+	assert.Equal(t, Money(123400), got.PricingPlans[0].UnitPrice, "prices must be parsed exactly")
+
+	_, err = LoadFromReader(strings.NewReader("rounding: {stage: daily}"))
+	assert.EqualError(t, err, "could not parse rounding: unknown stage \"daily\", expected final, monthly, or lineItem", "error message wrong")
+	_, err = LoadFromReader(strings.NewReader("rounding: {stage: final, decimals: 7}"))
+	assert.EqualError(t, err, "could not parse rounding: decimals must be between 0 and 6, got 7", "error message wrong")
+}
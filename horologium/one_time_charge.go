@@ -0,0 +1,66 @@
+package horologium
+
+import (
+	"sort"
+	"time"
+)
+
+// OneTimeCharge is an amount that is not billed per unit or month, e.g. a connection fee,
+// the rental of a meter, or a new-customer bonus. Credits are represented by negative amounts.
+// Optionally, the amount can be spread evenly over several months, e.g. a bonus distributed
+// over the first year of a contract.
+type OneTimeCharge struct {
+	Name   string    // a name for the charge
+	Amount Money     // the charged amount; negative for credits and bonuses
+	Date   time.Time // the date on which the amount is charged, or the first date if the amount is spread
+	Months int       // if greater than one, the amount is spread over this number of months, starting at Date
+}
+
+// Between returns the part of the charge that falls between start (inclusive) and end (exclusive).
+// A spread charge is split into equal parts, charged on the same day of every month beginning with Date;
+// in months without that day, e.g. the 31st, the part is charged on the last day of the month.
+// If the amount cannot be split exactly, the parts differ by at most a millionth such that they sum up to the amount.
+func (o *OneTimeCharge) Between(start time.Time, end time.Time) Money {
+	parts := Money(o.Months)
+	if parts < 1 {
+		parts = 1
+	}
+	result := Money(0)
+	for index := Money(0); index < parts; index++ {
+		date := addMonths(o.Date, int(index), o.Date.Day())
+		if !date.Before(start) && date.Before(end) {
+			result = result + o.Amount*(index+1)/parts - o.Amount*index/parts
+		}
+	}
+	return result
+}
+
+// OneTimeCharges is a slice of one-time charges.
+type OneTimeCharges []OneTimeCharge
+
+// Sort sorts the one-time charges in ascending order by their date.
+func (o OneTimeCharges) Sort() {
+	sort.SliceStable(o, func(i, j int) bool {
+		return o[i].Date.Before(o[j].Date)
+	})
+}
+
+// Between returns the sum of all charges between start (inclusive) and end (exclusive)
+// (see OneTimeCharge.Between).
+func (o OneTimeCharges) Between(start time.Time, end time.Time) Money {
+	result := Money(0)
+	for index := range o {
+		result = result + o[index].Between(start, end)
+	}
+	return result
+}
+
+// oneTimeCharges returns the one-time charges of the series between start and end,
+// treating every charge as line item that is rounded if the rounding rule of the series demands it.
+func (s *Series) oneTimeCharges(start time.Time, end time.Time) Money {
+	result := Money(0)
+	for index := range s.OneTimeCharges {
+		result = result + s.Rounding.round(RoundLineItem, s.OneTimeCharges[index].Between(start, end))
+	}
+	return result
+}
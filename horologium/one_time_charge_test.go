@@ -0,0 +1,76 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestOneTimeCharge_Between(t *testing.T) {
+	fee := OneTimeCharge{Name: "Connection fee", Amount: NewMoney(50), Date: CreateDate(2021, 3, 15)}
+	bonus := OneTimeCharge{Name: "Bonus", Amount: NewMoney(-120), Date: CreateDate(2021, 1, 1), Months: 12}
+	tests := []struct {
+		name   string
+		charge OneTimeCharge
+		start  int
+		end    int
+		want   Money
+	}{
+		{name: "fee included", charge: fee, start: 3, end: 4, want: NewMoney(50)},
+		{name: "fee excluded", charge: fee, start: 4, end: 5, want: 0},
+		{name: "spread bonus one month", charge: bonus, start: 2, end: 3, want: NewMoney(-10)},
+		{name: "spread bonus three months", charge: bonus, start: 1, end: 4, want: NewMoney(-30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.charge.Between(CreateDate(2021, tt.start, 1), CreateDate(2021, tt.end, 1))
+			assert.Equal(t, tt.want, got, "charged amount is wrong")
+		})
+	}
+	assert.Equal(t, NewMoney(-120), bonus.Between(CreateDate(2021, 1, 1), CreateDate(2022, 1, 1)), "whole bonus should be spread over the year")
+	charges := OneTimeCharges{fee, bonus}
+	assert.Equal(t, NewMoney(30), charges.Between(CreateDate(2021, 2, 1), CreateDate(2021, 4, 1)), "sum of charges is wrong")
+}
+
+func ExampleMonthlyStatistics_RenderTable_oneTimeCharges() {
+	series := Series{
+		PricingPlans:   PricingPlans{{BasePrice: NewMoney(10), UnitPrice: NewMoney(1)}},
+		MeterReadings:  MeterReadings{{Date: CreateDate(2021, 1, 1), Count: 0}, {Date: CreateDate(2021, 3, 1), Count: 118}},
+		OneTimeCharges: OneTimeCharges{{Name: "Bonus", Amount: NewMoney(-24), Date: CreateDate(2021, 1, 1), Months: 12}},
+	}
+	series.MonthlyStatistics(CreateDate(2021, 1, 1), CreateDate(2021, 3, 1)).RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR | CONSUMPTION | COSTS  | ONE-TIME |
+	// |-----------|------|-------------|--------|----------|
+	// | January   | 2021 |       62.00 |  70.00 |    -2.00 |
+	// | February  |      |       56.00 |  64.00 |    -2.00 |
+	// |-----------|------|-------------|--------|----------|
+	// | TOTAL     |      |      118.00 | 134.00 |    -4.00 |
+	// |-----------|------|-------------|--------|----------|
+}
+
+func TestLoadFromReader_OneTimeCharges(t *testing.T) {
+	file := `oneTimeCharges:
+  - {name: "Bonus", amount: -100, date: 2021-01-01, months: 12}`
+	got, err := LoadFromReader(strings.NewReader(file))
+	require.NoError(t, err, "loading the file failed")
+	require.Equal(t, 1, len(got.OneTimeCharges), "number of one-time charges is wrong")
+	assert.Equal(t, OneTimeCharge{Name: "Bonus", Amount: NewMoney(-100), Date: CreateDate(2021, 1, 1), Months: 12}, got.OneTimeCharges[0], "one-time charge is wrong")
+}
+
+func TestOneTimeCharge_Between_EndOfMonth(t *testing.T) {
+	bonus := OneTimeCharge{Name: "Bonus", Amount: NewMoney(-40), Date: CreateDate(2021, 1, 31), Months: 4}
+	for month := 1; month < 5; month++ {
+		got := bonus.Between(CreateDate(2021, month, 1), CreateDate(2021, month+1, 1))
+		assert.Equal(t, NewMoney(-10), got, "every month should get one part, also month %d", month)
+	}
+}
+
+func TestOneTimeCharge_Between_Exact(t *testing.T) {
+	fee := OneTimeCharge{Name: "Fee", Amount: NewMoney(100), Date: CreateDate(2021, 1, 1), Months: 3}
+	assert.Equal(t, Money(33333333), fee.Between(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1)), "first part is wrong")
+	assert.Equal(t, Money(33333334), fee.Between(CreateDate(2021, 3, 1), CreateDate(2021, 4, 1)), "last part is wrong")
+	assert.Equal(t, NewMoney(100), fee.Between(CreateDate(2021, 1, 1), CreateDate(2021, 4, 1)), "parts must sum up to the amount")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/goccy/go-yaml"
 	"io"
+	"strings"
 	"time"
 )
 
@@ -25,12 +26,95 @@ func LoadFromReader(reader io.Reader) (*Series, error) {
 	return series.mapToDomain()
 }
 
+// LoadTariffFromReader reads a tariff from the yaml file provided by the reader. The file has the
+// same format as a series file, but only the name, the plans, the one-time charges, the price caps,
+// and the relief credits are considered.
+// In case of parsing errors, an error is returned.
+func LoadTariffFromReader(reader io.Reader) (*Tariff, error) {
+	series, err := LoadFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &Tariff{Name: series.Name, PricingPlans: series.PricingPlans, OneTimeCharges: series.OneTimeCharges,
+		PriceCaps: series.PriceCaps, ReliefCredits: series.ReliefCredits}, nil
+}
+
+// LoadBenchmarkFromReader reads a benchmark from the yaml file provided by the reader (see Benchmark).
+// In case of parsing errors, an error is returned.
+func LoadBenchmarkFromReader(reader io.Reader) (*Benchmark, error) {
+	buf := new(bytes.Buffer)
+	_, err := buf.ReadFrom(reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not read reader: %v", err)
+	}
+	benchmark := benchmarkDto{}
+	err = yaml.Unmarshal(buf.Bytes(), &benchmark)
+	if err != nil {
+		formatError := yaml.FormatError(err, true, true)
+		return nil, fmt.Errorf("unmarshalling yaml failed: " + formatError)
+	}
+	return benchmark.mapToDomain()
+}
+
+type benchmarkDto struct {
+	Name        string
+	Unit        string
+	Basis       string
+	Percentiles []float64
+	Bands       []string
+	Rows        []benchmarkRowDto
+}
+
+type benchmarkRowDto struct {
+	Occupants int
+	Values    []float64
+}
+
+func (b *benchmarkDto) mapToDomain() (*Benchmark, error) {
+	result := &Benchmark{Name: b.Name, Basis: BenchmarkBasis(b.Basis), Percentiles: b.Percentiles, Bands: b.Bands}
+	if b.Unit != "" {
+		unit, err := ParseUnit(b.Unit)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse unit: %v", err)
+		}
+		result.Unit = unit
+	}
+	for _, row := range b.Rows {
+		result.Rows = append(result.Rows, BenchmarkRow{Occupants: row.Occupants, Values: row.Values})
+	}
+	if err := result.validate(); err != nil {
+		return nil, fmt.Errorf("invalid benchmark: %v", err)
+	}
+	return result, nil
+}
+
 type seriesDto struct {
 	Name              string
 	ConsumptionFormat string `json:"consumptionFormat"`
 	CurrencyFormat    string `json:"currencyFormat"`
+	Currency          string
+	MeterFormat       string `json:"meterFormat"`
+	Unit              string
+	MeterUnit         string `json:"meterUnit"`
 	Plans             []pricingPlanDto
 	Readings          []meterReadingDto
+	AdvancePayments   []advancePaymentDto   `json:"advancePayments"`
+	PriceCaps         []priceCapDto         `json:"priceCaps"`
+	ReliefCredits     []reliefCreditDto     `json:"reliefCredits"`
+	OneTimeCharges    []oneTimeChargeDto    `json:"oneTimeCharges"`
+	ConversionFactors []conversionFactorDto `json:"conversionFactors"`
+	EmissionFactors   []emissionFactorDto   `json:"emissionFactors"`
+	Budgets           []budgetDto
+	Goals             []goalDto
+	Contracts         []contractDto
+	Meters            []meterDto
+	Events            []eventDto
+	Households        []householdDto
+	IgnoreEstimates   bool                `json:"ignoreEstimates"`
+	ReadingInterval   *readingIntervalDto `json:"readingInterval"`
+	Rounding          *roundingDto
+	Interpolation     *interpolationDto
+	Heating           bool
 }
 
 func (s *seriesDto) mapToDomain() (*Series, error) {
@@ -40,6 +124,10 @@ func (s *seriesDto) mapToDomain() (*Series, error) {
 		if err != nil {
 			return nil, fmt.Errorf("could not parse plan %d: %v", index, err)
 		}
+		if domainPlan.ValidFrom == nil && index > 0 {
+			// a plan without validFrom is valid since ever, so it has to be the first one
+			return nil, fmt.Errorf("could not parse plan %d: validFrom is missing, only the first plan may omit it", index)
+		}
 		plans = append(plans, *domainPlan)
 	}
 	readings := make([]MeterReading, 0, len(s.Readings))
@@ -50,26 +138,194 @@ func (s *seriesDto) mapToDomain() (*Series, error) {
 		}
 		readings = append(readings, *domainReading)
 	}
+	payments := make([]AdvancePayment, 0, len(s.AdvancePayments))
+	for index, payment := range s.AdvancePayments {
+		domainPayment, err := payment.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse advance payment %d: %v", index, err)
+		}
+		payments = append(payments, *domainPayment)
+	}
+	priceCaps := make([]PriceCap, 0, len(s.PriceCaps))
+	for index, priceCap := range s.PriceCaps {
+		domainPriceCap, err := priceCap.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse price cap %d: %v", index, err)
+		}
+		priceCaps = append(priceCaps, *domainPriceCap)
+	}
+	credits := make([]ReliefCredit, 0, len(s.ReliefCredits))
+	for index, credit := range s.ReliefCredits {
+		domainCredit, err := credit.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse relief credit %d: %v", index, err)
+		}
+		credits = append(credits, *domainCredit)
+	}
+	charges := make([]OneTimeCharge, 0, len(s.OneTimeCharges))
+	for index, charge := range s.OneTimeCharges {
+		domainCharge, err := charge.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse one-time charge %d: %v", index, err)
+		}
+		charges = append(charges, *domainCharge)
+	}
+	factors := make([]ConversionFactor, 0, len(s.ConversionFactors))
+	for index, factor := range s.ConversionFactors {
+		domainFactor, err := factor.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse conversion factor %d: %v", index, err)
+		}
+		factors = append(factors, *domainFactor)
+	}
+	emissionFactors := make([]EmissionFactor, 0, len(s.EmissionFactors))
+	for index, factor := range s.EmissionFactors {
+		domainFactor, err := factor.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse emission factor %d: %v", index, err)
+		}
+		emissionFactors = append(emissionFactors, *domainFactor)
+	}
+	budgets := make([]Budget, 0, len(s.Budgets))
+	for index, budget := range s.Budgets {
+		domainBudget, err := budget.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse budget %d: %v", index, err)
+		}
+		budgets = append(budgets, *domainBudget)
+	}
+	goals := make([]Goal, 0, len(s.Goals))
+	for index, goal := range s.Goals {
+		domainGoal, err := goal.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse goal %d: %v", index, err)
+		}
+		goals = append(goals, *domainGoal)
+	}
+	contracts := make([]Contract, 0, len(s.Contracts))
+	for index, contract := range s.Contracts {
+		domainContract, err := contract.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse contract %d: %v", index, err)
+		}
+		contracts = append(contracts, *domainContract)
+	}
+	meters := make([]Meter, 0, len(s.Meters))
+	for index, meter := range s.Meters {
+		domainMeter, err := meter.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse meter %d: %v", index, err)
+		}
+		meters = append(meters, *domainMeter)
+	}
+	events := make([]Event, 0, len(s.Events))
+	for index, event := range s.Events {
+		domainEvent, err := event.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse event %d: %v", index, err)
+		}
+		events = append(events, *domainEvent)
+	}
+	households := make([]Household, 0, len(s.Households))
+	for index, household := range s.Households {
+		domainHousehold, err := household.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse household %d: %v", index, err)
+		}
+		households = append(households, *domainHousehold)
+	}
+	unit, meterUnit, err := s.units(len(factors) > 0)
+	if err != nil {
+		return nil, err
+	}
+	rounding := RoundingRule{}
+	if s.Rounding != nil {
+		domainRounding, err := s.Rounding.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse rounding: %v", err)
+		}
+		rounding = *domainRounding
+	}
+	var interpolation Interpolation
+	if s.Interpolation != nil {
+		interpolation, err = s.Interpolation.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse interpolation: %v", err)
+		}
+	}
+	var readingInterval *ReadingInterval
+	if s.ReadingInterval != nil {
+		readingInterval, err = s.ReadingInterval.mapToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse reading interval: %v", err)
+		}
+	}
 	return &Series{
 		Name:              s.Name,
 		ConsumptionFormat: s.ConsumptionFormat,
 		CurrencyFormat:    s.CurrencyFormat,
+		Currency:          strings.ToUpper(s.Currency),
 		PricingPlans:      plans,
-		MeterReadings:     readings}, nil
+		MeterReadings:     readings,
+		AdvancePayments:   payments,
+		PriceCaps:         priceCaps,
+		ReliefCredits:     credits,
+		OneTimeCharges:    charges,
+		ConversionFactors: factors,
+		EmissionFactors:   emissionFactors,
+		MeterFormat:       s.MeterFormat,
+		Unit:              unit,
+		MeterUnit:         meterUnit,
+		Rounding:          rounding,
+		Interpolation:     interpolation,
+		Heating:           s.Heating,
+		Budgets:           budgets,
+		Goals:             goals,
+		ReadingInterval:   readingInterval,
+		Contracts:         contracts,
+		Meters:            meters,
+		IgnoreEstimates:   s.IgnoreEstimates,
+		Events:            events,
+		Households:        households}, nil
+}
+
+// units parses the unit and the meter unit of the series. Without conversion factors,
+// the meter unit must be convertible into the unit.
+func (s *seriesDto) units(conversion bool) (Unit, Unit, error) {
+	var unit, meterUnit Unit
+	var err error
+	if s.Unit != "" {
+		if unit, err = ParseUnit(s.Unit); err != nil {
+			return unit, meterUnit, fmt.Errorf("could not parse unit: %v", err)
+		}
+	}
+	if s.MeterUnit != "" {
+		if meterUnit, err = ParseUnit(s.MeterUnit); err != nil {
+			return unit, meterUnit, fmt.Errorf("could not parse meter unit: %v", err)
+		}
+	}
+	if !conversion && unit.Known() && meterUnit.Known() && unit.Dimension != meterUnit.Dimension {
+		return unit, meterUnit, fmt.Errorf("the meter unit %s cannot be converted to %s without conversion factors", meterUnit, unit)
+	}
+	return unit, meterUnit, nil
 }
 
 type pricingPlanDto struct {
 	Name      string
-	BasePrice float64 `json:"basePrice"`
-	UnitPrice float64 `json:"unitPrice"`
+	BasePrice Money   `json:"basePrice"`
+	UnitPrice Money   `json:"unitPrice"`
 	ValidFrom string  `json:"validFrom"`
 	ValidTo   *string `json:"validTo"`
 }
 
 func (p *pricingPlanDto) mapToDomain() (*PricingPlan, error) {
-	validFrom, err := time.Parse(DateFormat, p.ValidFrom)
-	if err != nil {
-		return nil, fmt.Errorf("could not parse validFrom date: %v", err)
+	var validFrom *time.Time
+	if p.ValidFrom != "" {
+		validFromVal, err := time.Parse(DateFormat, p.ValidFrom)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse validFrom date: %v", err)
+		}
+		validFrom = &validFromVal
 	}
 	var validTo *time.Time
 	if p.ValidTo != nil {
@@ -79,12 +335,17 @@ func (p *pricingPlanDto) mapToDomain() (*PricingPlan, error) {
 		}
 		validTo = &validToVal
 	}
-	return &PricingPlan{ValidFrom: &validFrom, ValidTo: validTo, Name: p.Name, BasePrice: p.BasePrice, UnitPrice: p.UnitPrice}, nil
+	return &PricingPlan{ValidFrom: validFrom, ValidTo: validTo, Name: p.Name, BasePrice: p.BasePrice, UnitPrice: p.UnitPrice}, nil
 }
 
+// readingSources maps the source names of series files to the reading sources.
+var readingSources = map[string]ReadingSource{"actual": ReadingActual, "estimated": ReadingEstimated, "provider": ReadingProvider, "imported": ReadingImported}
+
 type meterReadingDto struct {
-	Count float64
-	Date  string
+	Count  float64
+	Date   string
+	Source string
+	Note   string
 }
 
 func (m *meterReadingDto) mapToDomain() (*MeterReading, error) {
@@ -92,5 +353,411 @@ func (m *meterReadingDto) mapToDomain() (*MeterReading, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not parse date: %v", err)
 	}
-	return &MeterReading{Date: date, Count: m.Count}, nil
+	source := ReadingActual
+	if m.Source != "" {
+		var ok bool
+		source, ok = readingSources[m.Source]
+		if !ok {
+			return nil, fmt.Errorf("unknown source \"%s\", expected actual, estimated, provider, or imported", m.Source)
+		}
+	}
+	return &MeterReading{Date: date, Count: m.Count, Source: source, Note: m.Note}, nil
+}
+
+type contractDto struct {
+	Provider          string
+	CustomerNumber    string  `json:"customerNumber"`
+	MeterNumber       string  `json:"meterNumber"`
+	ValidFrom         string  `json:"validFrom"`
+	MinimumTerm       string  `json:"minimumTerm"`
+	NoticePeriod      string  `json:"noticePeriod"`
+	Renewal           string  `json:"renewal"`
+	PriceGuaranteeEnd *string `json:"priceGuaranteeEnd"`
+}
+
+func (c *contractDto) mapToDomain() (*Contract, error) {
+	validFrom, err := time.Parse(DateFormat, c.ValidFrom)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validFrom date: %v", err)
+	}
+	priceGuaranteeEnd, err := parseOptionalDate(c.PriceGuaranteeEnd)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse priceGuaranteeEnd date: %v", err)
+	}
+	minimumTerm, err := parseOptionalTerm(c.MinimumTerm)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse minimumTerm: %v", err)
+	}
+	noticePeriod, err := parseOptionalTerm(c.NoticePeriod)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse noticePeriod: %v", err)
+	}
+	renewal, err := parseOptionalTerm(c.Renewal)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse renewal: %v", err)
+	}
+	return &Contract{
+		Provider:          c.Provider,
+		CustomerNumber:    c.CustomerNumber,
+		MeterNumber:       c.MeterNumber,
+		ValidFrom:         validFrom,
+		MinimumTerm:       minimumTerm,
+		NoticePeriod:      noticePeriod,
+		Renewal:           renewal,
+		PriceGuaranteeEnd: priceGuaranteeEnd,
+	}, nil
+}
+
+type meterDto struct {
+	SerialNumber       string  `json:"serialNumber"`
+	Installed          string  `json:"installed"`
+	CalibrationExpires *string `json:"calibrationExpires"`
+}
+
+func (m *meterDto) mapToDomain() (*Meter, error) {
+	installed, err := time.Parse(DateFormat, m.Installed)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse installed date: %v", err)
+	}
+	calibrationExpires, err := parseOptionalDate(m.CalibrationExpires)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse calibrationExpires date: %v", err)
+	}
+	return &Meter{SerialNumber: m.SerialNumber, Installed: installed, CalibrationExpires: calibrationExpires}, nil
+}
+
+type eventDto struct {
+	Label     string
+	ValidFrom string `json:"validFrom"`
+	ValidTo   string `json:"validTo"`
+	Exclude   bool
+}
+
+func (e *eventDto) mapToDomain() (*Event, error) {
+	validFrom, err := time.Parse(DateFormat, e.ValidFrom)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validFrom date: %v", err)
+	}
+	validTo, err := time.Parse(DateFormat, e.ValidTo)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validTo date: %v", err)
+	}
+	if !validFrom.Before(validTo) {
+		return nil, fmt.Errorf("the event must end after it starts")
+	}
+	return &Event{Label: e.Label, ValidFrom: validFrom, ValidTo: validTo, Exclude: e.Exclude}, nil
+}
+
+type householdDto struct {
+	Occupants int
+	Area      float64
+	ValidFrom string `json:"validFrom"`
+}
+
+func (h *householdDto) mapToDomain() (*Household, error) {
+	validFrom, err := time.Parse(DateFormat, h.ValidFrom)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validFrom date: %v", err)
+	}
+	if h.Occupants < 0 || h.Area < 0 {
+		return nil, fmt.Errorf("occupants and area must not be negative")
+	}
+	return &Household{Occupants: h.Occupants, Area: h.Area, ValidFrom: validFrom}, nil
+}
+
+type readingIntervalDto struct {
+	Months    *int
+	Day       int
+	Tolerance int
+}
+
+func (r *readingIntervalDto) mapToDomain() (*ReadingInterval, error) {
+	months := 1
+	if r.Months != nil {
+		months = *r.Months
+	}
+	if months < 1 {
+		return nil, fmt.Errorf("months must be positive, got %d", months)
+	}
+	if r.Day < 0 || r.Day > 31 {
+		return nil, fmt.Errorf("day must be between 1 and 31, got %d", r.Day)
+	}
+	if r.Tolerance < 0 {
+		return nil, fmt.Errorf("tolerance must not be negative, got %d", r.Tolerance)
+	}
+	return &ReadingInterval{Months: months, Day: r.Day, Tolerance: r.Tolerance}, nil
+}
+
+type advancePaymentDto struct {
+	Amount    Money
+	ValidFrom string  `json:"validFrom"`
+	ValidTo   *string `json:"validTo"`
+}
+
+func (a *advancePaymentDto) mapToDomain() (*AdvancePayment, error) {
+	validFrom, err := time.Parse(DateFormat, a.ValidFrom)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validFrom date: %v", err)
+	}
+	validTo, err := parseOptionalDate(a.ValidTo)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validTo date: %v", err)
+	}
+	return &AdvancePayment{Amount: a.Amount, ValidFrom: validFrom, ValidTo: validTo}, nil
+}
+
+type budgetDto struct {
+	Amount    Money
+	Monthly   []Money
+	ValidFrom string  `json:"validFrom"`
+	ValidTo   *string `json:"validTo"`
+}
+
+func (b *budgetDto) mapToDomain() (*Budget, error) {
+	validFrom, err := time.Parse(DateFormat, b.ValidFrom)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validFrom date: %v", err)
+	}
+	validTo, err := parseOptionalDate(b.ValidTo)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validTo date: %v", err)
+	}
+	if b.Amount != 0 && b.Monthly != nil {
+		return nil, fmt.Errorf("either an amount or monthly budgets can be given")
+	}
+	if b.Monthly != nil && len(b.Monthly) != 12 {
+		return nil, fmt.Errorf("expected 12 monthly budgets, got %d", len(b.Monthly))
+	}
+	if b.Amount < 0 {
+		return nil, fmt.Errorf("amount must not be negative")
+	}
+	for _, amount := range b.Monthly {
+		if amount < 0 {
+			return nil, fmt.Errorf("monthly budgets must not be negative")
+		}
+	}
+	return &Budget{Amount: b.Amount, Monthly: b.Monthly, ValidFrom: validFrom, ValidTo: validTo}, nil
+}
+
+type goalDto struct {
+	Name         string
+	BaselineFrom string `json:"baselineFrom"`
+	BaselineTo   string `json:"baselineTo"`
+	Reduction    float64
+	ValidFrom    string `json:"validFrom"`
+	ValidTo      string `json:"validTo"`
+}
+
+func (g *goalDto) mapToDomain() (*Goal, error) {
+	baselineFrom, err := time.Parse(DateFormat, g.BaselineFrom)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse baselineFrom date: %v", err)
+	}
+	baselineTo, err := time.Parse(DateFormat, g.BaselineTo)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse baselineTo date: %v", err)
+	}
+	validFrom, err := time.Parse(DateFormat, g.ValidFrom)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validFrom date: %v", err)
+	}
+	validTo, err := time.Parse(DateFormat, g.ValidTo)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validTo date: %v", err)
+	}
+	if !baselineFrom.Before(baselineTo) || !validFrom.Before(validTo) {
+		return nil, fmt.Errorf("the periods must end after they start")
+	}
+	if g.Reduction < 0 || g.Reduction > 100 {
+		return nil, fmt.Errorf("reduction must be between 0 and 100 percent, got %v", g.Reduction)
+	}
+	return &Goal{Name: g.Name, BaselineFrom: baselineFrom, BaselineTo: baselineTo, Reduction: g.Reduction / 100, ValidFrom: validFrom, ValidTo: validTo}, nil
+}
+
+type priceCapDto struct {
+	Name                 string
+	CappedPrice          Money `json:"cappedPrice"`
+	Quota                float64
+	ReferenceConsumption float64 `json:"referenceConsumption"`
+	ValidFrom            string  `json:"validFrom"`
+	ValidTo              *string `json:"validTo"`
+}
+
+func (p *priceCapDto) mapToDomain() (*PriceCap, error) {
+	validFrom, err := time.Parse(DateFormat, p.ValidFrom)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validFrom date: %v", err)
+	}
+	validTo, err := parseOptionalDate(p.ValidTo)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validTo date: %v", err)
+	}
+	return &PriceCap{
+		Name:                 p.Name,
+		CappedPrice:          p.CappedPrice,
+		Quota:                p.Quota,
+		ReferenceConsumption: p.ReferenceConsumption,
+		ValidFrom:            validFrom,
+		ValidTo:              validTo,
+	}, nil
+}
+
+type reliefCreditDto struct {
+	Name   string
+	Amount Money
+	Date   string
+}
+
+func (r *reliefCreditDto) mapToDomain() (*ReliefCredit, error) {
+	date, err := time.Parse(DateFormat, r.Date)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse date: %v", err)
+	}
+	return &ReliefCredit{Name: r.Name, Amount: r.Amount, Date: date}, nil
+}
+
+type oneTimeChargeDto struct {
+	Name   string
+	Amount Money
+	Date   string
+	Months int
+}
+
+func (o *oneTimeChargeDto) mapToDomain() (*OneTimeCharge, error) {
+	date, err := time.Parse(DateFormat, o.Date)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse date: %v", err)
+	}
+	return &OneTimeCharge{Name: o.Name, Amount: o.Amount, Date: date, Months: o.Months}, nil
+}
+
+func parseOptionalTerm(term string) (Term, error) {
+	if term == "" {
+		return Term{}, nil
+	}
+	return ParseTerm(term)
+}
+
+func parseOptionalDate(date *string) (*time.Time, error) {
+	if date == nil {
+		return nil, nil
+	}
+	result, err := time.Parse(DateFormat, *date)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+type conversionFactorDto struct {
+	CalorificValue float64  `json:"calorificValue"`
+	StateNumber    *float64 `json:"stateNumber"`
+	ValidFrom      string   `json:"validFrom"`
+}
+
+func (c *conversionFactorDto) mapToDomain() (*ConversionFactor, error) {
+	validFrom, err := time.Parse(DateFormat, c.ValidFrom)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validFrom date: %v", err)
+	}
+	if c.CalorificValue <= 0 {
+		return nil, fmt.Errorf("calorific value must be positive, got %v", c.CalorificValue)
+	}
+	stateNumber := 1.0
+	if c.StateNumber != nil {
+		stateNumber = *c.StateNumber
+	}
+	return &ConversionFactor{CalorificValue: c.CalorificValue, StateNumber: stateNumber, ValidFrom: validFrom}, nil
+}
+
+type emissionFactorDto struct {
+	Emission      float64
+	PrimaryEnergy float64 `json:"primaryEnergy"`
+	ValidFrom     string  `json:"validFrom"`
+}
+
+func (e *emissionFactorDto) mapToDomain() (*EmissionFactor, error) {
+	validFrom, err := time.Parse(DateFormat, e.ValidFrom)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse validFrom date: %v", err)
+	}
+	if e.Emission < 0 || e.PrimaryEnergy < 0 {
+		return nil, fmt.Errorf("emission and primary energy must not be negative")
+	}
+	return &EmissionFactor{Emission: e.Emission, PrimaryEnergy: e.PrimaryEnergy, ValidFrom: validFrom}, nil
+}
+
+// roundingStages maps the stage names of series files to the rounding stages.
+var roundingStages = map[string]RoundingStage{"final": RoundFinal, "monthly": RoundMonthly, "lineItem": RoundLineItem}
+
+// roundingModes maps the mode names of series files to the rounding modes.
+var roundingModes = map[string]RoundingMode{"halfUp": RoundHalfUp, "halfEven": RoundHalfEven}
+
+type roundingDto struct {
+	Stage    string
+	Mode     string
+	Decimals *int
+}
+
+func (r *roundingDto) mapToDomain() (*RoundingRule, error) {
+	stage, ok := roundingStages[r.Stage]
+	if !ok {
+		return nil, fmt.Errorf("unknown stage \"%s\", expected final, monthly, or lineItem", r.Stage)
+	}
+	mode := RoundHalfUp
+	if r.Mode != "" {
+		mode, ok = roundingModes[r.Mode]
+		if !ok {
+			return nil, fmt.Errorf("unknown mode \"%s\", expected halfUp or halfEven", r.Mode)
+		}
+	}
+	decimals := 2
+	if r.Decimals != nil {
+		decimals = *r.Decimals
+	}
+	if decimals < 0 || decimals > moneyDecimals {
+		return nil, fmt.Errorf("decimals must be between 0 and %d, got %d", moneyDecimals, decimals)
+	}
+	return &RoundingRule{Stage: stage, Mode: mode, Decimals: decimals}, nil
+}
+
+type interpolationDto struct {
+	Method  string
+	Profile string
+	Weights []float64
+}
+
+func (i *interpolationDto) mapToDomain() (Interpolation, error) {
+	switch i.Method {
+	case "linear":
+		return LinearInterpolation{}, nil
+	case "step":
+		return StepInterpolation{}, nil
+	case "profile":
+		return i.profile()
+	case "degreeDays":
+		return DegreeDayInterpolation{}, nil
+	}
+	return nil, fmt.Errorf("unknown method \"%s\", expected linear, step, profile, or degreeDays", i.Method)
+}
+
+// profile parses a profile interpolation, which either uses a named profile or twelve monthly weights.
+func (i *interpolationDto) profile() (Interpolation, error) {
+	if i.Profile == "h0" && len(i.Weights) == 0 {
+		return ProfileInterpolation{Profile: H0Profile{}}, nil
+	}
+	if i.Profile != "" {
+		return nil, fmt.Errorf("unknown profile \"%s\", expected h0 or monthly weights", i.Profile)
+	}
+	if len(i.Weights) != 12 {
+		return nil, fmt.Errorf("expected 12 monthly weights, got %d", len(i.Weights))
+	}
+	var profile MonthlyProfile
+	for index, weight := range i.Weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("weights must not be negative")
+		}
+		profile[index] = weight
+	}
+	return ProfileInterpolation{Profile: profile}, nil
 }
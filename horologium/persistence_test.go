@@ -71,15 +71,15 @@ func TestSeries_MapToDomain(t *testing.T) {
 	to := "2020-02-29"
 	plan1 := pricingPlanDto{
 		Name:      "Year 2000",
-		BasePrice: 1202.23,
-		UnitPrice: 19.2,
+		BasePrice: NewMoney(1202.23),
+		UnitPrice: NewMoney(19.2),
 		ValidFrom: "2000-01-01",
 		ValidTo:   &to,
 	}
 	plan2 := pricingPlanDto{
 		Name:      "To Infinity",
-		BasePrice: 1823.12,
-		UnitPrice: 27.23,
+		BasePrice: NewMoney(1823.12),
+		UnitPrice: NewMoney(27.23),
 		ValidFrom: "2020-03-01",
 		ValidTo:   nil,
 	}
@@ -97,7 +97,7 @@ func TestSeries_MapToDomain(t *testing.T) {
 		wantReadingErr bool
 		wantErrMessage string
 	}{
-		{name: "wrong plan", wantPlanErr: true, wantReadingErr: false, wantErrMessage: "could not parse plan 0: could not parse validFrom date: parsing time \"10.04.2004\" as \"2006-01-02\": cannot parse \"4.2004\" as \"2006\""},
+		{name: "wrong plan", wantPlanErr: true, wantReadingErr: false, wantErrMessage: "could not parse plan 0: could not parse validFrom date: parsing time \"10.04.2004\" as \"2006-01-02\": cannot parse \"10.04.2004\" as \"2006\""},
 		{name: "wrong reading", wantPlanErr: false, wantReadingErr: true, wantErrMessage: "could not parse reading 0: could not parse date: parsing time \"----\" as \"2006-01-02\": cannot parse \"----\" as \"2006\""},
 		{name: "success", wantPlanErr: false, wantReadingErr: false, wantErrMessage: ""},
 	}
@@ -136,6 +136,16 @@ func TestSeries_MapToDomain(t *testing.T) {
 	}
 }
 
+func TestLoadFromReader_PlansWithoutValidFrom(t *testing.T) {
+	got, err := LoadFromReader(strings.NewReader("plans:\n  - {name: old, unitPrice: 2}\n  - {name: new, unitPrice: 1, validFrom: 2020-01-01}"))
+	require.NoError(t, err, "the first plan may omit validFrom")
+	assert.Nil(t, got.PricingPlans[0].ValidFrom, "the first plan should be valid since ever")
+	_, err = LoadFromReader(strings.NewReader("plans:\n  - {name: new, unitPrice: 1, validFrom: 2020-01-01}\n  - {name: old, unitPrice: 2}"))
+	assert.EqualError(t, err, "could not parse plan 1: validFrom is missing, only the first plan may omit it", "error message wrong")
+	_, err = LoadFromReader(strings.NewReader("plans:\n  - {name: old}\n  - {name: older}"))
+	assert.EqualError(t, err, "could not parse plan 1: validFrom is missing, only the first plan may omit it", "error message wrong")
+}
+
 func TestPricingPlan_MapToDomain(t *testing.T) {
 	to := "2018-08-13"
 	notADate := "notADate"
@@ -154,7 +164,7 @@ func TestPricingPlan_MapToDomain(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			plan := pricingPlanDto{UnitPrice: 34.23, BasePrice: 1023.12, ValidTo: tt.validTo, ValidFrom: tt.validFrom, Name: "Unit Test Plan"}
+			plan := pricingPlanDto{UnitPrice: NewMoney(34.23), BasePrice: NewMoney(1023.12), ValidTo: tt.validTo, ValidFrom: tt.validFrom, Name: "Unit Test Plan"}
 			got, err := plan.mapToDomain()
 			if err != nil || tt.wantErr != nil {
 				assert.Nil(t, got, "got should be nil in case of an error")
@@ -168,8 +178,8 @@ func TestPricingPlan_MapToDomain(t *testing.T) {
 					wantValidTo = &t
 				}
 				assert.Equal(t, wantValidTo, got.ValidTo, "validTo is wrong")
-				assert.Equal(t, 1023.12, got.BasePrice, "basePrice  is wrong")
-				assert.Equal(t, 34.23, got.UnitPrice, "unitPrice is wrong")
+				assert.Equal(t, NewMoney(1023.12), got.BasePrice, "basePrice  is wrong")
+				assert.Equal(t, NewMoney(34.23), got.UnitPrice, "unitPrice is wrong")
 			}
 		})
 	}
@@ -177,17 +187,21 @@ func TestPricingPlan_MapToDomain(t *testing.T) {
 
 func TestMeterReading_MapToDomain(t *testing.T) {
 	tests := []struct {
-		name     string
-		date     string
-		wantTime time.Time
-		wantErr  error
+		name       string
+		date       string
+		source     string
+		wantTime   time.Time
+		wantSource ReadingSource
+		wantErr    error
 	}{
 		{name: "success", date: "2018-05-31", wantTime: CreateDate(2018, 5, 31), wantErr: nil},
+		{name: "estimated", date: "2018-05-31", source: "estimated", wantTime: CreateDate(2018, 5, 31), wantSource: ReadingEstimated},
 		{name: "wrong date", date: "not a date", wantErr: errors.New("could not parse date: parsing time \"not a date\" as \"2006-01-02\": cannot parse \"not a date\" as \"2006\"")},
+		{name: "unknown source", date: "2018-05-31", source: "guessed", wantErr: errors.New("unknown source \"guessed\", expected actual, estimated, provider, or imported")},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			reading := meterReadingDto{Date: tt.date, Count: 34.3}
+			reading := meterReadingDto{Date: tt.date, Count: 34.3, Source: tt.source, Note: "a note"}
 			got, err := reading.mapToDomain()
 			if tt.wantErr != nil || err != nil {
 				assert.Nil(t, got, "got should be nil if an error occurred")
@@ -195,6 +209,8 @@ func TestMeterReading_MapToDomain(t *testing.T) {
 			} else {
 				assert.Equal(t, reading.Count, got.Count, "count is wrong")
 				assert.Equal(t, tt.wantTime, got.Date, "date is wrong")
+				assert.Equal(t, tt.wantSource, got.Source, "source is wrong")
+				assert.Equal(t, "a note", got.Note, "note is wrong")
 			}
 		})
 	}
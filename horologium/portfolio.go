@@ -0,0 +1,95 @@
+package horologium
+
+import (
+	"io"
+	"time"
+)
+
+// Portfolio combines several series, e.g. the power, gas, and water series of a household.
+type Portfolio []*Series
+
+// PortfolioStatistics contains the monthly statistics of every series of a portfolio.
+// Since the series of a portfolio usually measure different units, consumptions are only
+// available per series. Costs, however, can be summed up over all series.
+type PortfolioStatistics struct {
+	Names      []string            // the names of the series, in the order of the portfolio
+	Statistics []MonthlyStatistics // the monthly statistics of the series, in the order of the portfolio
+}
+
+// MonthlyStatistics computes the monthly statistics of every series in the portfolio for the specified time span
+// (see Series.MonthlyStatistics).
+func (p Portfolio) MonthlyStatistics(start time.Time, end time.Time) PortfolioStatistics {
+	result := PortfolioStatistics{Names: make([]string, 0, len(p)), Statistics: make([]MonthlyStatistics, 0, len(p))}
+	for _, series := range p {
+		result.Names = append(result.Names, series.Name)
+		result.Statistics = append(result.Statistics, series.MonthlyStatistics(start, end))
+	}
+	return result
+}
+
+// MonthlyCosts returns the costs of all series summed up per month.
+// The result contains one Statistics per month; its consumption is always zero
+// because consumptions of different series cannot be added.
+func (p PortfolioStatistics) MonthlyCosts() MonthlyStatistics {
+	result := make(MonthlyStatistics, 0)
+	for _, stats := range p.Statistics {
+		for index, stat := range stats {
+			if index >= len(result) {
+				result = append(result, Statistics{ValidFrom: stat.ValidFrom, ValidTo: stat.ValidTo, CurrencyFormat: stat.CurrencyFormat, Currency: stat.Currency})
+			}
+			result[index].Costs = result[index].Costs + stat.Costs
+		}
+	}
+	return result
+}
+
+// TotalCosts returns the costs of all series over the whole time span, i.e. the sum
+// of the total costs of every series (see MonthlyStatistics.Total).
+func (p PortfolioStatistics) TotalCosts() Money {
+	result := Money(0)
+	for _, stats := range p.Statistics {
+		_, costs := stats.Total()
+		result = result + costs
+	}
+	return result
+}
+
+// RenderTable renders an overview of the portfolio's costs as table with one
+// column per series and an additional column containing the household total (see example).
+func (p PortfolioStatistics) RenderTable(writer io.Writer) {
+	monthlyCosts := p.MonthlyCosts()
+	headers := make([]tableColumn, 0, len(p.Names)+1)
+	for _, name := range p.Names {
+		headers = append(headers, tableColumn{header: name})
+	}
+	headers = append(headers, tableColumn{header: "TOTAL"})
+	dates := make([]time.Time, 0, len(monthlyCosts))
+	rows := make([][]string, 0, len(monthlyCosts))
+	for index, month := range monthlyCosts {
+		row := make([]string, 0, len(headers))
+		for _, stats := range p.Statistics {
+			stat := Statistics{}
+			if index < len(stats) {
+				stat = stats[index]
+			}
+			row = append(row, stat.FormatCosts())
+		}
+		dates = append(dates, month.ValidFrom)
+		rows = append(rows, append(row, month.FormatCosts()))
+	}
+	total := make([]string, 0, len(headers))
+	for _, stats := range p.Statistics {
+		_, costs := stats.Total()
+		total = append(total, formatCurrency(stats, costs))
+	}
+	total = append(total, formatCurrency(monthlyCosts, p.TotalCosts()))
+	renderMonthlyTable(writer, headers, dates, rows, total)
+}
+
+func formatCurrency(stats MonthlyStatistics, value Money) string {
+	stat := Statistics{Costs: value}
+	if len(stats) > 0 {
+		stat.CurrencyFormat = stats[0].CurrencyFormat
+	}
+	return stat.FormatCosts()
+}
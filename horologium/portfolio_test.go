@@ -0,0 +1,45 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func testPortfolio() Portfolio {
+	power := &Series{
+		Name:          "Power",
+		PricingPlans:  PricingPlans{{BasePrice: NewMoney(10), UnitPrice: NewMoney(0.3)}},
+		MeterReadings: MeterReadings{{Date: CreateDate(2020, 1, 1), Count: 100}, {Date: CreateDate(2020, 3, 1), Count: 700}},
+	}
+	water := &Series{
+		Name:          "Water",
+		PricingPlans:  PricingPlans{{BasePrice: NewMoney(5), UnitPrice: NewMoney(2)}},
+		MeterReadings: MeterReadings{{Date: CreateDate(2020, 1, 1), Count: 10}, {Date: CreateDate(2020, 3, 1), Count: 22}},
+	}
+	return Portfolio{power, water}
+}
+
+func TestPortfolio_MonthlyStatistics(t *testing.T) {
+	stats := testPortfolio().MonthlyStatistics(CreateDate(2020, 1, 1), CreateDate(2020, 3, 1))
+	assert.Equal(t, []string{"Power", "Water"}, stats.Names, "names are wrong")
+	assert.Equal(t, 2, len(stats.Statistics), "there should be statistics for every series")
+	monthly := stats.MonthlyCosts()
+	assert.Equal(t, 2, len(monthly), "there should be two months")
+	assert.Equal(t, NewMoney(10+310*0.3+5+2*310.0/50), monthly[0].Costs, "costs of January are wrong")
+	assert.Equal(t, 0.0, monthly[0].Consumption, "consumptions must not be summed up")
+	assert.Equal(t, monthly[0].Costs+monthly[1].Costs, stats.TotalCosts(), "total costs are wrong")
+}
+
+func ExamplePortfolioStatistics_RenderTable() {
+	stats := testPortfolio().MonthlyStatistics(CreateDate(2020, 1, 1), CreateDate(2020, 3, 1))
+	stats.RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR | Power  | Water | TOTAL  |
+	// |-----------|------|--------|-------|--------|
+	// | January   | 2020 | 103.00 | 17.40 | 120.40 |
+	// | February  |      |  97.00 | 16.60 | 113.60 |
+	// |-----------|------|--------|-------|--------|
+	// | TOTAL     |      | 200.00 | 34.00 | 234.00 |
+	// |-----------|------|--------|-------|--------|
+}
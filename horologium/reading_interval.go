@@ -0,0 +1,128 @@
+package horologium
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// ReadingInterval describes how often the meter of a series is read, e.g. monthly on the 1st.
+type ReadingInterval struct {
+	Months    int // the number of months between two readings, e.g. 1 for monthly readings
+	Day       int // the day of the month on which readings are due; 0 means the day of the last reading
+	Tolerance int // the number of days a reading may be late without counting as a gap (see Series.Gaps)
+}
+
+// Next returns the date on which the reading after a reading at the given date is due.
+// If the due day does not exist in a month, the reading is due on the last day of the month.
+func (r ReadingInterval) Next(last time.Time) time.Time {
+	day := r.Day
+	if day == 0 {
+		day = last.Day()
+	}
+	return addMonths(last, r.Months, day)
+}
+
+// addMonths adds the months to the month of the date and returns the given day of the resulting month.
+// If the day does not exist in the month, the last day of the month is returned.
+func addMonths(date time.Time, months int, day int) time.Time {
+	month := CreateDate(date.Year(), int(date.Month()), 1).AddDate(0, months, 0)
+	if days := firstOfNextMonth(month).AddDate(0, 0, -1).Day(); day > days {
+		day = days
+	}
+	return month.AddDate(0, 0, day-1)
+}
+
+// Gap is a time span between two consecutive meter readings that is longer than the reading interval.
+// The consumption within a gap is only interpolated and may differ considerably from the real consumption.
+type Gap struct {
+	From time.Time // the date of the reading before the gap
+	To   time.Time // the date of the reading after the gap
+	Due  time.Time // the date on which the missing reading was due
+}
+
+// String returns a warning describing the gap.
+func (g Gap) String() string {
+	days := math.Round(g.To.Sub(g.From).Hours() / 24)
+	return fmt.Sprintf("the consumption between %s and %s is interpolated over %.0f days (a reading was due on %s)",
+		g.From.Format(DateFormat), g.To.Format(DateFormat), days, g.Due.Format(DateFormat))
+}
+
+// Gaps returns all gaps overlapping the time span between start and end: consecutive meter readings
+// where the later reading was taken more than the tolerance after its due date. Without reading interval,
+// there are no gaps.
+func (s *Series) Gaps(start time.Time, end time.Time) []Gap {
+	if s.ReadingInterval == nil {
+		return nil
+	}
+	sorted := append(MeterReadings{}, s.MeterReadings...)
+	sorted.Sort()
+	result := make([]Gap, 0)
+	for index := 0; index+1 < len(sorted); index++ {
+		from, to := sorted[index].Date, sorted[index+1].Date
+		due := s.ReadingInterval.Next(from)
+		if to.After(due.AddDate(0, 0, s.ReadingInterval.Tolerance)) && from.Before(end) && to.After(start) {
+			result = append(result, Gap{From: from, To: to, Due: due})
+		}
+	}
+	return result
+}
+
+// NextReading returns the date on which the next reading of the series is due. If the series has no reading
+// interval or no meter readings, false is returned.
+func (s *Series) NextReading() (time.Time, bool) {
+	if s.ReadingInterval == nil || len(s.MeterReadings) == 0 {
+		return time.Time{}, false
+	}
+	last := s.MeterReadings[0].Date
+	for _, reading := range s.MeterReadings {
+		if reading.Date.After(last) {
+			last = reading.Date
+		}
+	}
+	return s.ReadingInterval.Next(last), true
+}
+
+// ReadingReminder reminds of a reading that is overdue or due soon.
+type ReadingReminder struct {
+	Name string    // the name of the series
+	Due  time.Time // the date on which the reading is due
+}
+
+// ReadingReminders is a slice of reading reminders.
+type ReadingReminders []ReadingReminder
+
+// DueReadings returns a reminder for every series whose next reading is due within the given number of days
+// after the date or is already overdue. The reminders are sorted by their due date.
+func (p Portfolio) DueReadings(date time.Time, days int) ReadingReminders {
+	result := make(ReadingReminders, 0, len(p))
+	limit := date.AddDate(0, 0, days)
+	for _, series := range p {
+		if due, ok := series.NextReading(); ok && !due.After(limit) {
+			result = append(result, ReadingReminder{Name: series.Name, Due: due})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Due.Before(result[j].Due)
+	})
+	return result
+}
+
+// RenderTable renders the reminders as table with the status of every reading relative to the given date.
+func (r ReadingReminders) RenderTable(writer io.Writer, date time.Time) {
+	columns := []tableColumn{{header: "SERIES", left: true}, {header: "DUE", left: true}, {header: "STATUS", left: true}}
+	rows := make([][]string, 0, len(r))
+	for _, reminder := range r {
+		days := int(math.Round(reminder.Due.Sub(date).Hours() / 24))
+		status := "due today"
+		if days < 0 {
+			status = fmt.Sprintf("overdue by %d day(s)", -days)
+		} else if days > 0 {
+			status = fmt.Sprintf("due in %d day(s)", days)
+		}
+		rows = append(rows, []string{reminder.Name, reminder.Due.Format(DateFormat), status})
+	}
+	renderTable(writer, columns, rows, nil)
+}
@@ -0,0 +1,80 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadingInterval_Next(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval ReadingInterval
+		last     string
+		want     string
+	}{
+		{name: "monthly on the 1st", interval: ReadingInterval{Months: 1, Day: 1}, last: "2023-01-01", want: "2023-02-01"},
+		{name: "late reading", interval: ReadingInterval{Months: 1, Day: 1}, last: "2023-01-04", want: "2023-02-01"},
+		{name: "quarterly", interval: ReadingInterval{Months: 3, Day: 15}, last: "2023-01-15", want: "2023-04-15"},
+		{name: "day of the last reading", interval: ReadingInterval{Months: 1}, last: "2023-01-31", want: "2023-02-28"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			last, _ := time.Parse(DateFormat, tt.last)
+			want, _ := time.Parse(DateFormat, tt.want)
+			assert.Equal(t, want, tt.interval.Next(last), "due date is wrong")
+		})
+	}
+}
+
+func testIntervalSeries() *Series {
+	return &Series{
+		Name:            "Water",
+		ReadingInterval: &ReadingInterval{Months: 1, Day: 1, Tolerance: 3},
+		MeterReadings: MeterReadings{
+			{Date: CreateDate(2023, 1, 1), Count: 0},
+			{Date: CreateDate(2023, 2, 3), Count: 10},
+			{Date: CreateDate(2023, 5, 1), Count: 40},
+			{Date: CreateDate(2023, 6, 1), Count: 50},
+		},
+	}
+}
+
+func TestSeries_Gaps(t *testing.T) {
+	series := testIntervalSeries()
+	got := series.Gaps(CreateDate(2023, 1, 1), CreateDate(2023, 7, 1))
+	require.Equal(t, 1, len(got), "only the missing readings should be a gap")
+	assert.Equal(t, Gap{From: CreateDate(2023, 2, 3), To: CreateDate(2023, 5, 1), Due: CreateDate(2023, 3, 1)}, got[0], "gap is wrong")
+	assert.Equal(t, "the consumption between 2023-02-03 and 2023-05-01 is interpolated over 87 days (a reading was due on 2023-03-01)", got[0].String(), "warning is wrong")
+	assert.Empty(t, series.Gaps(CreateDate(2023, 5, 1), CreateDate(2023, 7, 1)), "gaps outside the time span should be ignored")
+	series.ReadingInterval = nil
+	assert.Empty(t, series.Gaps(CreateDate(2023, 1, 1), CreateDate(2023, 7, 1)), "without interval, there should be no gaps")
+}
+
+func ExampleReadingReminders_RenderTable() {
+	gas := testIntervalSeries()
+	gas.Name = "Gas"
+	gas.ReadingInterval = &ReadingInterval{Months: 3, Day: 1}
+	power := testIntervalSeries()
+	power.Name = "Power"
+	power.MeterReadings = append(power.MeterReadings, MeterReading{Date: CreateDate(2023, 7, 1), Count: 60})
+	irregular := &Series{Name: "Heat", MeterReadings: MeterReadings{{Date: CreateDate(2023, 1, 1)}}}
+	Portfolio{testIntervalSeries(), gas, power, irregular}.DueReadings(CreateDate(2023, 7, 3), 30).RenderTable(os.Stdout, CreateDate(2023, 7, 3))
+	// Output:
+	// | SERIES |    DUE     |       STATUS        |
+	// |--------|------------|---------------------|
+	// | Water  | 2023-07-01 | overdue by 2 day(s) |
+	// | Power  | 2023-08-01 | due in 29 day(s)    |
+	// |--------|------------|---------------------|
+}
+
+func TestLoadFromReader_ReadingInterval(t *testing.T) {
+	got, err := LoadFromReader(strings.NewReader("readingInterval: {day: 1}"))
+	require.NoError(t, err, "loading failed")
+	assert.Equal(t, &ReadingInterval{Months: 1, Day: 1}, got.ReadingInterval, "reading interval is wrong")
+	_, err = LoadFromReader(strings.NewReader("readingInterval: {months: 0}"))
+	assert.EqualError(t, err, "could not parse reading interval: months must be positive, got 0", "error message wrong")
+}
@@ -0,0 +1,99 @@
+package horologium
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// PriceCap caps the unit price for a quota of the consumption, e.g. the German price brake
+// capped the unit price for 80 % of a reference consumption. Consumption above the quota is
+// billed with the unit price of the pricing plan.
+type PriceCap struct {
+	Name                 string     // a name for the price cap
+	CappedPrice          Money      // the unit price for consumption within the quota
+	Quota                float64    // the share of the reference consumption with capped price, e.g. 0.8
+	ReferenceConsumption float64    // the reference consumption per year
+	ValidFrom            time.Time  // the start time from which the price cap is valid
+	ValidTo              *time.Time // the end time from which the price cap is not valid any more
+}
+
+// PriceCaps is a slice of price caps.
+type PriceCaps []PriceCap
+
+// Sort sorts the price caps in ascending order by their ValidFrom date.
+func (p PriceCaps) Sort() {
+	sort.SliceStable(p, func(i, j int) bool {
+		return p[i].ValidFrom.Before(p[j].ValidFrom)
+	})
+}
+
+// ReliefCredit is a one-time relief credited on a certain date, e.g. a state subsidy.
+type ReliefCredit struct {
+	Name   string    // a name for the credit
+	Amount Money     // the credited amount
+	Date   time.Time // the date on which the amount is credited
+}
+
+// ReliefCredits is a slice of relief credits.
+type ReliefCredits []ReliefCredit
+
+// Sort sorts the relief credits in ascending order by their date.
+func (r ReliefCredits) Sort() {
+	sort.SliceStable(r, func(i, j int) bool {
+		return r[i].Date.Before(r[j].Date)
+	})
+}
+
+// Relief computes the relief granted between start (inclusive) and end (exclusive) by the
+// price caps and relief credits of the series.
+//
+// The quota of a price cap accrues evenly over its validity, i.e. after d days the cap has granted d/365 of
+// the yearly quota. Like in the annual settlement of a price brake, the consumption within the quota is counted
+// cumulatively from the start of the cap, and a time span gets the part of the capped consumption that falls into it.
+// This carries over in both directions: quota that is not used in one month is available in the following ones,
+// and consumption above the quota in one month is credited as soon as later months accrue unused quota. Hence,
+// a month with little consumption after one with much consumption may be granted more relief than its own
+// consumption would allow. In return, the relief of consecutive months adds up to the relief of the whole span.
+// For consumption within the quota, the relief is the difference between the unit price of the pricing plan and
+// the capped price (if the unit price is higher). Relief credits are granted in full if their date is between
+// start and end.
+//
+// Every relief of a price cap within a pricing plan as well as every relief credit is a line item
+// and rounded if the rounding rule of the series demands it.
+func (s *Series) Relief(start time.Time, end time.Time) Money {
+	relief := Money(0)
+	for _, priceCap := range s.PriceCaps {
+		capStart := priceCap.ValidFrom
+		if capStart.Before(start) {
+			capStart = start
+		}
+		capEnd := end
+		if priceCap.ValidTo != nil && priceCap.ValidTo.Before(end) {
+			capEnd = *priceCap.ValidTo
+		}
+		if !capStart.Before(capEnd) {
+			continue
+		}
+		for _, segment := range s.planSegments(capStart, capEnd) {
+			capped := s.cappedConsumption(priceCap, segment.end) - s.cappedConsumption(priceCap, segment.start)
+			if segment.plan.UnitPrice > priceCap.CappedPrice {
+				relief = relief + s.Rounding.round(RoundLineItem, (segment.plan.UnitPrice-priceCap.CappedPrice).Multiply(capped))
+			}
+		}
+	}
+	for _, credit := range s.ReliefCredits {
+		if !credit.Date.Before(start) && credit.Date.Before(end) {
+			relief = relief + s.Rounding.round(RoundLineItem, credit.Amount)
+		}
+	}
+	return relief
+}
+
+// cappedConsumption returns the consumption between the start of the price cap and the date that is within
+// the quota accrued until the date.
+func (s *Series) cappedConsumption(priceCap PriceCap, date time.Time) float64 {
+	days := math.Round(date.Sub(priceCap.ValidFrom).Hours() / 24)
+	quota := priceCap.ReferenceConsumption * priceCap.Quota * days / 365
+	return math.Min(s.Consumption(priceCap.ValidFrom, date), quota)
+}
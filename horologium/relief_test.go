@@ -0,0 +1,125 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testReliefSeries() *Series {
+	// 10 units per day, the yearly quota of the price cap is 0.8 * 3650 = 2920 units, i.e. 8 units per day
+	return &Series{
+		PricingPlans: PricingPlans{{UnitPrice: NewMoney(0.5), BasePrice: NewMoney(10)}},
+		MeterReadings: MeterReadings{
+			{Date: CreateDate(2023, 1, 1), Count: 0},
+			{Date: CreateDate(2023, 3, 2), Count: 600},
+		},
+		PriceCaps:     PriceCaps{{Name: "Price brake", CappedPrice: NewMoney(0.4), Quota: 0.8, ReferenceConsumption: 3650, ValidFrom: CreateDate(2023, 2, 1)}},
+		ReliefCredits: ReliefCredits{{Name: "December relief", Amount: NewMoney(25), Date: CreateDate(2023, 1, 15)}},
+	}
+}
+
+func TestSeries_Relief(t *testing.T) {
+	series := testReliefSeries()
+	tests := []struct {
+		name  string
+		start int
+		end   int
+		want  Money
+	}{
+		{name: "only credit", start: 1, end: 2, want: NewMoney(25)},
+		{name: "only price cap", start: 2, end: 3, want: NewMoney(22.4)},
+		{name: "both", start: 1, end: 3, want: NewMoney(47.4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := series.Relief(CreateDate(2023, tt.start, 1), CreateDate(2023, tt.end, 1))
+			assert.Equal(t, tt.want, got, "relief is wrong")
+		})
+	}
+}
+
+func TestSeries_Relief_ConsumptionBelowQuota(t *testing.T) {
+	series := testReliefSeries()
+	series.PriceCaps[0].ReferenceConsumption = 36500
+	got := series.Relief(CreateDate(2023, 2, 1), CreateDate(2023, 3, 1))
+	assert.Equal(t, NewMoney(28), got, "relief must not exceed the actual consumption")
+	series.PriceCaps[0].CappedPrice = NewMoney(0.6)
+	got = series.Relief(CreateDate(2023, 2, 1), CreateDate(2023, 3, 1))
+	assert.Equal(t, Money(0), got, "cap above the unit price must not grant relief")
+}
+
+func TestSeries_Relief_MonthsAddUp(t *testing.T) {
+	series := testReliefSeries()
+	// 5 units per day in February, 11 units per day in March and April
+	series.MeterReadings = MeterReadings{
+		{Date: CreateDate(2023, 2, 1), Count: 0},
+		{Date: CreateDate(2023, 3, 1), Count: 140},
+		{Date: CreateDate(2023, 5, 1), Count: 140 + 61*11},
+	}
+	validTo := CreateDate(2023, 5, 1)
+	series.PriceCaps[0].ValidTo = &validTo
+	total := series.Relief(CreateDate(2023, 2, 1), CreateDate(2023, 5, 1))
+	// the quota of 89 days * 8 units is used up completely, the unused quota of February is used later
+	assert.Equal(t, NewMoney(0.1*89*8), total, "relief of the whole period is wrong")
+	sum := Money(0)
+	for month := 2; month < 5; month++ {
+		sum = sum + series.Relief(CreateDate(2023, month, 1), CreateDate(2023, month+1, 1))
+	}
+	assert.Equal(t, total, sum, "the relief of the months should add up to the relief of the whole period")
+	assert.Equal(t, NewMoney(14), series.Relief(CreateDate(2023, 2, 1), CreateDate(2023, 3, 1)), "February consumption is below the quota")
+}
+
+func TestSeries_Relief_HighMonthThenLowMonth(t *testing.T) {
+	series := testReliefSeries()
+	// 11 units per day in February, 2 units per day in March
+	series.MeterReadings = MeterReadings{
+		{Date: CreateDate(2023, 2, 1), Count: 0},
+		{Date: CreateDate(2023, 3, 1), Count: 308},
+		{Date: CreateDate(2023, 4, 1), Count: 370},
+	}
+	february := series.Relief(CreateDate(2023, 2, 1), CreateDate(2023, 3, 1))
+	assert.Equal(t, NewMoney(0.1*28*8), february, "the relief of February should be limited by its quota")
+	march := series.Relief(CreateDate(2023, 3, 1), CreateDate(2023, 4, 1))
+	// the unused quota of March takes over the 84 units of February above the quota
+	assert.Equal(t, NewMoney(0.1*(62+84)), march, "the excess of February should be credited in March")
+	assert.Equal(t, series.Relief(CreateDate(2023, 2, 1), CreateDate(2023, 4, 1)), february+march, "the months should add up to the whole span")
+}
+
+func TestSeries_CostsAndConsumption_Relief(t *testing.T) {
+	series := testReliefSeries()
+	costs, consumption := series.CostsAndConsumption(CreateDate(2023, 2, 1), CreateDate(2023, 3, 1))
+	assert.Equal(t, 280.0, consumption, "consumption is wrong")
+	assert.Equal(t, NewMoney(10+140-22.4), costs, "relief should be subtracted from the costs")
+}
+
+func ExampleMonthlyStatistics_RenderTable_relief() {
+	series := testReliefSeries()
+	series.MonthlyStatistics(CreateDate(2023, 1, 1), CreateDate(2023, 3, 1)).RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR | CONSUMPTION | COSTS  | RELIEF |
+	// |-----------|------|-------------|--------|--------|
+	// | January   | 2023 |      310.00 | 140.00 |  25.00 |
+	// | February  |      |      280.00 | 127.60 |  22.40 |
+	// |-----------|------|-------------|--------|--------|
+	// | TOTAL     |      |      590.00 | 267.60 |  47.40 |
+	// |-----------|------|-------------|--------|--------|
+}
+
+func TestLoadFromReader_Relief(t *testing.T) {
+	file := `priceCaps:
+  - {name: "Price brake", cappedPrice: 0.4, quota: 0.8, referenceConsumption: 3500, validFrom: 2023-01-01, validTo: 2024-01-01}
+reliefCredits:
+  - {name: "December relief", amount: 120.5, date: 2022-12-01}`
+	got, err := LoadFromReader(strings.NewReader(file))
+	require.NoError(t, err, "loading the file failed")
+	require.Equal(t, 1, len(got.PriceCaps), "number of price caps is wrong")
+	assert.Equal(t, PriceCap{Name: "Price brake", CappedPrice: NewMoney(0.4), Quota: 0.8, ReferenceConsumption: 3500, ValidFrom: CreateDate(2023, 1, 1), ValidTo: formatDatePtr(2024, 1, 1)}, got.PriceCaps[0], "price cap is wrong")
+	require.Equal(t, 1, len(got.ReliefCredits), "number of relief credits is wrong")
+	assert.Equal(t, ReliefCredit{Name: "December relief", Amount: NewMoney(120.5), Date: CreateDate(2022, 12, 1)}, got.ReliefCredits[0], "relief credit is wrong")
+
+	_, err = LoadFromReader(strings.NewReader("reliefCredits:\n  - {amount: 3, date: 1.1.2020}"))
+	assert.EqualError(t, err, "could not parse relief credit 0: could not parse date: parsing time \"1.1.2020\" as \"2006-01-02\": cannot parse \"1.1.2020\" as \"2006\"", "error message wrong")
+}
@@ -5,6 +5,8 @@ import (
 	"io"
 	"math"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // MonthlyStatistics is a slice of Statistics which contain one Statistics per month
@@ -12,67 +14,282 @@ import (
 type MonthlyStatistics []Statistics
 
 // Total returns the sum of consumptions and costs (in this order)
-// of the statistics.
-func (m MonthlyStatistics) Total() (float64, float64) {
+// of the statistics. The costs are rounded according to the rounding rule of the statistics.
+func (m MonthlyStatistics) Total() (float64, Money) {
 	consumption := 0.0
-	costs := 0.0
+	costs := Money(0)
+	rounding := RoundingRule{}
 	for _, part := range m {
 		consumption = consumption + part.Consumption
 		costs = costs + part.Costs
+		rounding = part.Rounding
 	}
-	return consumption, costs
+	return consumption, rounding.round(RoundFinal, costs)
 }
 
 // RenderTable converts the MonthlyStatistics to a nice-looking table (see example).
 // This method assumes that the MonthlyStatistics are sorted (earliest month first).
+// If the unit of the consumption is known, it is shown in the header of the consumption column.
+//
+// Optional columns are only rendered if the statistics contain values for them: the consumption in the meter unit
+// if it differs from the billing unit (see ConversionFactor), the weather-normalized consumption (see DegreeDays), the one-time charges,
+// the relief granted by price caps and relief credits, the emissions and primary energy (see Series.Footprint),
+// the budget and the variance (budget minus costs, positive values mean the costs stayed within the budget),
+// the advance payments of each month
+// together with the running balance of the billing period (positive values mean a refund is expected),
+// the consumption and costs per occupant and per m² of living area (see Households),
+// as well as the labels of the events of each month.
 func (s MonthlyStatistics) RenderTable(writer io.Writer) {
-	rows := make([][]string, 0, len(s))
 	consumptionFormat := "%.2f"
+	meterFormat := "%.2f"
 	currencyFormat := "%.2f"
+	consumptionHeader := "CONSUMPTION"
+	meterHeader := "METER"
+	totalRawConsumption := 0.0
+	totalNormalized := 0.0
+	totalEmissions := 0.0
+	totalPrimaryEnergy := 0.0
 	for _, stat := range s {
-		month := stat.ValidFrom.Month().String()
-		year := fmt.Sprintf("%d", stat.ValidFrom.Year())
-		cons := stat.FormatConsumption()
-		costs := stat.FormatCosts()
+		totalRawConsumption = totalRawConsumption + stat.RawConsumption
+		totalNormalized = totalNormalized + stat.Normalized
+		totalEmissions = totalEmissions + stat.Emissions
+		totalPrimaryEnergy = totalPrimaryEnergy + stat.PrimaryEnergy
+		if stat.Unit.Known() {
+			consumptionHeader = "CONSUMPTION (" + stat.Unit.Symbol + ")"
+		}
+		if stat.MeterUnit.Known() {
+			meterHeader = "METER (" + stat.MeterUnit.Symbol + ")"
+		}
 		if stat.ConsumptionFormat != "" {
 			consumptionFormat = stat.ConsumptionFormat
 		}
+		if stat.MeterFormat != "" {
+			meterFormat = stat.MeterFormat
+		}
 		if stat.CurrencyFormat != "" {
 			currencyFormat = stat.CurrencyFormat
 		}
-		row := []string{month, year, cons, costs}
-		rows = append(rows, row)
 	}
+	currency := func(value func(stat Statistics) Money) func(stat Statistics) string {
+		return func(stat Statistics) string {
+			return fmt.Sprintf(currencyFormat, value(stat))
+		}
+	}
+	nonZero := func(value func(stat Statistics) Money) func(stat Statistics) bool {
+		return func(stat Statistics) bool {
+			return value(stat) != 0
+		}
+	}
+	relief := func(stat Statistics) Money { return stat.Relief }
+	charges := func(stat Statistics) Money { return stat.OneTimeCharges }
+	payments := func(stat Statistics) Money { return stat.Payments }
+	budget := func(stat Statistics) Money { return stat.Budget }
 	totalConsumption, totalCosts := s.Total()
-	rows = append(rows, []string{"TOTAL", "", fmt.Sprintf(consumptionFormat, totalConsumption), fmt.Sprintf(currencyFormat, totalCosts)})
-	yearColLength := 6
-	monthColLength := 11
-	consColLength := int(math.Max(float64(longestEntry(2, rows)), 13))
-	costsColLength := int(math.Max(float64(longestEntry(3, rows)), 7))
-	_, _ = fmt.Fprintf(writer, "|%s|%s|%s|%s|\n", padCenter(monthColLength, "MONTH"), padCenter(yearColLength, "YEAR"), padCenter(consColLength, "CONSUMPTION"), padCenter(costsColLength, "COSTS"))
-	_, _ = fmt.Fprintf(writer, "|%s|%s|%s|%s|\n", repeat(monthColLength, "-"), repeat(yearColLength, "-"), repeat(consColLength, "-"), repeat(costsColLength, "-"))
+	total := Statistics{Consumption: totalConsumption, Costs: totalCosts}
+	total.Occupants, total.Area = s.household()
+	columns := []statisticsColumn{
+		{header: consumptionHeader, value: func(stat Statistics) string { return stat.FormatConsumption() }, total: fmt.Sprintf(consumptionFormat, totalConsumption)},
+		{header: meterHeader, value: func(stat Statistics) string { return stat.FormatRawConsumption() }, total: fmt.Sprintf(meterFormat, totalRawConsumption),
+			optional: func(stat Statistics) bool { return stat.RawConsumption != 0 && stat.RawConsumption != stat.Consumption }},
+		{header: "NORMALIZED", value: func(stat Statistics) string { return fmt.Sprintf(consumptionFormat, stat.Normalized) },
+			total: fmt.Sprintf(consumptionFormat, totalNormalized), optional: func(stat Statistics) bool { return stat.Normalized != 0 }},
+		{header: "COSTS", value: func(stat Statistics) string { return stat.FormatCosts() }, total: fmt.Sprintf(currencyFormat, totalCosts)},
+		{header: "ONE-TIME", value: currency(charges), total: fmt.Sprintf(currencyFormat, s.sum(charges)), optional: nonZero(charges)},
+		{header: "RELIEF", value: currency(relief), total: fmt.Sprintf(currencyFormat, s.sum(relief)), optional: nonZero(relief)},
+		{header: "CO₂ (kg)", value: func(stat Statistics) string { return fmt.Sprintf("%.1f", stat.Emissions) },
+			total: fmt.Sprintf("%.1f", totalEmissions), optional: func(stat Statistics) bool { return stat.Emissions != 0 }},
+		{header: "PRIMARY ENERGY", value: func(stat Statistics) string { return fmt.Sprintf("%.1f", stat.PrimaryEnergy) },
+			total: fmt.Sprintf("%.1f", totalPrimaryEnergy), optional: func(stat Statistics) bool { return stat.PrimaryEnergy != 0 }},
+		{header: "BUDGET", value: currency(budget), total: fmt.Sprintf(currencyFormat, s.sum(budget)), optional: nonZero(budget)},
+		{header: "VARIANCE", value: currency(func(stat Statistics) Money { return stat.Budget - stat.Costs }),
+			total: fmt.Sprintf(currencyFormat, s.sum(budget)-totalCosts), optional: nonZero(budget)},
+		{header: "PAYMENTS", value: currency(payments), total: fmt.Sprintf(currencyFormat, s.sum(payments)), optional: nonZero(payments)},
+		{header: "BALANCE", value: currency(func(stat Statistics) Money { return stat.Balance }), optional: nonZero(payments)},
+		{header: "PER PERSON", value: func(stat Statistics) string { return fmt.Sprintf(consumptionFormat, stat.PerOccupant()) },
+			total: fmt.Sprintf(consumptionFormat, total.PerOccupant()), optional: func(stat Statistics) bool { return stat.Occupants != 0 }},
+		{header: "PER m²", value: func(stat Statistics) string { return fmt.Sprintf(consumptionFormat, stat.PerArea()) },
+			total: fmt.Sprintf(consumptionFormat, total.PerArea()), optional: func(stat Statistics) bool { return stat.Area != 0 }},
+		{header: "COSTS PER PERSON", value: currency(func(stat Statistics) Money { return stat.CostsPerOccupant() }),
+			total: fmt.Sprintf(currencyFormat, total.CostsPerOccupant()), optional: func(stat Statistics) bool { return stat.Occupants != 0 }},
+		{header: "COSTS PER m²", value: currency(func(stat Statistics) Money { return stat.CostsPerArea() }),
+			total: fmt.Sprintf(currencyFormat, total.CostsPerArea()), optional: func(stat Statistics) bool { return stat.Area != 0 }},
+		{header: "EVENTS", value: func(stat Statistics) string { return stat.Events.labels() }, left: true,
+			optional: func(stat Statistics) bool { return len(stat.Events) > 0 }},
+	}
+	s.renderColumns(writer, columns)
+}
+
+// statisticsColumn describes a column of the table rendered by MonthlyStatistics.RenderTable.
+type statisticsColumn struct {
+	header   string                       // the header of the column
+	value    func(stat Statistics) string // the formatted value of a month
+	total    string                       // the value of the total row
+	optional func(stat Statistics) bool   // if not nil, the column is only rendered if the function returns true for a month
+	left     bool                         // whether the values are aligned left (default is right)
+}
+
+func (s MonthlyStatistics) renderColumns(writer io.Writer, columns []statisticsColumn) {
+	present := make([]statisticsColumn, 0, len(columns))
+	for _, column := range columns {
+		if column.optional == nil || s.any(column.optional) {
+			present = append(present, column)
+		}
+	}
+	headers := make([]tableColumn, 0, len(present))
+	total := make([]string, 0, len(present))
+	for _, column := range present {
+		headers = append(headers, tableColumn{header: column.header, left: column.left})
+		total = append(total, column.total)
+	}
+	dates := make([]time.Time, 0, len(s))
+	rows := make([][]string, 0, len(s))
+	for _, stat := range s {
+		row := make([]string, 0, len(present))
+		for _, column := range present {
+			row = append(row, column.value(stat))
+		}
+		dates = append(dates, stat.ValidFrom)
+		rows = append(rows, row)
+	}
+	renderMonthlyTable(writer, headers, dates, rows, total)
+}
+
+func (s MonthlyStatistics) sum(value func(stat Statistics) Money) Money {
+	result := Money(0)
+	for _, stat := range s {
+		result = result + value(stat)
+	}
+	return result
+}
+
+func (s MonthlyStatistics) any(predicate func(stat Statistics) bool) bool {
+	for _, stat := range s {
+		if predicate(stat) {
+			return true
+		}
+	}
+	return false
+}
+
+// TotalPayments returns the sum of the advance payments of the statistics.
+func (s MonthlyStatistics) TotalPayments() Money {
+	return s.sum(func(stat Statistics) Money { return stat.Payments })
+}
+
+// ByYear sums up the statistics per calendar year, e.g. for a yearly footprint. The result contains one
+// Statistics per year, valid from the start of the first to the end of the last month of the year.
+// The costs are rounded like a total (see Total); the balance is the balance at the end of the year.
+func (s MonthlyStatistics) ByYear() MonthlyStatistics {
+	years := make([]MonthlyStatistics, 0)
+	for _, stat := range s {
+		if len(years) == 0 || years[len(years)-1][0].ValidFrom.Year() != stat.ValidFrom.Year() {
+			years = append(years, MonthlyStatistics{})
+		}
+		years[len(years)-1] = append(years[len(years)-1], stat)
+	}
+	result := make(MonthlyStatistics, 0, len(years))
+	for _, months := range years {
+		year := months[len(months)-1]
+		year.ValidFrom = months[0].ValidFrom
+		year.Consumption, year.Costs = months.Total()
+		year.RawConsumption, year.Normalized, year.Emissions, year.PrimaryEnergy = 0, 0, 0, 0
+		for _, month := range months {
+			year.RawConsumption = year.RawConsumption + month.RawConsumption
+			year.Normalized = year.Normalized + month.Normalized
+			year.Emissions = year.Emissions + month.Emissions
+			year.PrimaryEnergy = year.PrimaryEnergy + month.PrimaryEnergy
+		}
+		year.Relief = months.sum(func(stat Statistics) Money { return stat.Relief })
+		year.OneTimeCharges = months.sum(func(stat Statistics) Money { return stat.OneTimeCharges })
+		year.Payments = months.TotalPayments()
+		year.Budget = months.sum(func(stat Statistics) Money { return stat.Budget })
+		year.Occupants, year.Area = months.household()
+		year.Events = Events{}
+		for _, month := range months {
+			for _, event := range month.Events {
+				if !year.Events.contains(event) {
+					year.Events = append(year.Events, event)
+				}
+			}
+		}
+		result = append(result, year)
+	}
+	return result
+}
+
+// renderMonthlyTable renders a table with one row per month. Apart from the month and year columns,
+// the table contains the given columns. The total row is appended at the end of the table.
+func renderMonthlyTable(writer io.Writer, headers []tableColumn, dates []time.Time, rows [][]string, total []string) {
+	columns := append([]tableColumn{{header: "MONTH", width: 11, left: true}, {header: "YEAR", width: 6, left: true}}, headers...)
+	cells := make([][]string, 0, len(rows))
 	for index, row := range rows {
-		month := fmt.Sprintf(" %-10v", row[0])
-		year := fmt.Sprintf(" %-5v", row[1])
-		if index > 0 && strings.Contains(year, rows[index-1][1]) {
-			year = repeat(yearColLength, " ")
+		year := fmt.Sprintf("%d", dates[index].Year())
+		if index > 0 && dates[index-1].Year() == dates[index].Year() {
+			year = ""
 		}
-		consumptionFormat := " %" + fmt.Sprintf("%d", consColLength-2) + "v "
-		consValue := fmt.Sprintf(consumptionFormat, row[2])
-		costsFormat := " %" + fmt.Sprintf("%d", costsColLength-2) + "v "
-		costs := fmt.Sprintf(costsFormat, row[3])
-		if index == len(rows)-1 {
-			_, _ = fmt.Fprintf(writer, "|%s|%s|%s|%s|\n", repeat(monthColLength, "-"), repeat(yearColLength, "-"), repeat(consColLength, "-"), repeat(costsColLength, "-"))
+		cells = append(cells, append([]string{dates[index].Month().String(), year}, row...))
+	}
+	renderTable(writer, columns, cells, append([]string{"TOTAL", ""}, total...))
+}
+
+type tableColumn struct {
+	header string // the header of the column
+	width  int    // the minimum width of the column
+	left   bool   // whether the values are aligned left (default is right)
+}
+
+// renderTable renders the rows as table with the given columns. Columns are at least as wide as their
+// header and their longest value. If the footer is not nil, it is rendered as last row, separated by a line.
+func renderTable(writer io.Writer, columns []tableColumn, rows [][]string, footer []string) {
+	allRows := rows[:len(rows):len(rows)]
+	if footer != nil {
+		allRows = append(allRows, footer)
+	}
+	widths := make([]int, len(columns))
+	for col, column := range columns {
+		widths[col] = int(math.Max(float64(longestEntry(col, allRows)), math.Max(float64(utf8.RuneCountInString(column.header)+2), float64(column.width))))
+	}
+	line := func(values []string) {
+		_, _ = fmt.Fprintf(writer, "|%s|\n", strings.Join(values, "|"))
+	}
+	separator := func() {
+		values := make([]string, len(widths))
+		for col, width := range widths {
+			values[col] = repeat(width, "-")
+		}
+		line(values)
+	}
+	cells := func(row []string) []string {
+		values := make([]string, len(widths))
+		for col, width := range widths {
+			if columns[col].left {
+				values[col] = fmt.Sprintf(" %-"+fmt.Sprintf("%d", width-1)+"v", row[col])
+			} else {
+				values[col] = fmt.Sprintf(" %"+fmt.Sprintf("%d", width-2)+"v ", row[col])
+			}
 		}
-		_, _ = fmt.Fprintf(writer, "|%s|%s|%s|%s|\n", month, year, consValue, costs)
+		return values
+	}
+	headers := make([]string, len(columns))
+	for col, column := range columns {
+		headers[col] = padCenter(widths[col], column.header)
+	}
+	line(headers)
+	separator()
+	for _, row := range rows {
+		line(cells(row))
+	}
+	if footer != nil {
+		separator()
+		line(cells(footer))
 	}
-	_, _ = fmt.Fprintf(writer, "|%s|%s|%s|%s|\n", repeat(monthColLength, "-"), repeat(yearColLength, "-"), repeat(consColLength, "-"), repeat(costsColLength, "-"))
+	separator()
 }
 
 func longestEntry(col int, rows [][]string) int {
 	max := 0
 	for _, row := range rows {
-		max = int(math.Max(float64(len(row[col])), float64(max)))
+		max = int(math.Max(float64(utf8.RuneCountInString(row[col])), float64(max)))
 	}
 	return max + 2
 }
@@ -86,9 +303,9 @@ func repeat(number int, text string) string {
 }
 
 func padCenter(totalSize int, text string) string {
-	padding := totalSize - len(text)
-	padLeftFormat := "%" + fmt.Sprintf("%d", padding/2+len(text)) + "v"
+	padding := totalSize - utf8.RuneCountInString(text)
+	padLeftFormat := "%" + fmt.Sprintf("%d", padding/2+utf8.RuneCountInString(text)) + "v"
 	result := fmt.Sprintf(padLeftFormat, text)
-	padRightFormat := "%-" + fmt.Sprintf("%d", (padding+1)/2+len(result)) + "v"
+	padRightFormat := "%-" + fmt.Sprintf("%d", (padding+1)/2+utf8.RuneCountInString(result)) + "v"
 	return fmt.Sprintf(padRightFormat, result)
 }
@@ -4,30 +4,30 @@ import (
 	"os"
 )
 
-func ExampleMonthlyStatistics_Render() {
+func ExampleMonthlyStatistics_RenderTable() {
 	stats := MonthlyStatistics{
 		{
 			ValidFrom:   CreateDate(2019, 12, 1),
 			ValidTo:     CreateDate(2020, 1, 1),
-			Costs:       1341.12,
+			Costs:       NewMoney(1341.12),
 			Consumption: 42.23,
 		},
 		{
 			ValidFrom:   CreateDate(2020, 1, 1),
 			ValidTo:     CreateDate(2020, 2, 1),
-			Costs:       1343.28,
+			Costs:       NewMoney(1343.28),
 			Consumption: 53.76,
 		},
 		{
 			ValidFrom:   CreateDate(2020, 2, 1),
 			ValidTo:     CreateDate(2020, 3, 1),
-			Costs:       3252.74,
+			Costs:       NewMoney(3252.74),
 			Consumption: 75.34,
 		},
 		{
 			ValidFrom:   CreateDate(2020, 3, 1),
 			ValidTo:     CreateDate(2020, 4, 1),
-			Costs:       633.28,
+			Costs:       NewMoney(633.28),
 			Consumption: 12.53,
 		},
 	}
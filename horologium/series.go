@@ -2,6 +2,7 @@ package horologium
 
 import (
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -9,8 +10,8 @@ import (
 // Additionally, a base price per month can be given, as well as a name.
 type PricingPlan struct {
 	Name      string     // a name for the pricing plan
-	BasePrice float64    // the monthly base price
-	UnitPrice float64    // the price for one unit
+	BasePrice Money      // the monthly base price
+	UnitPrice Money      // the price for one unit
 	ValidFrom *time.Time // the start time from which the pricing plan is valid
 	ValidTo   *time.Time // the end time from which the pricing plan is not valid any more
 }
@@ -18,18 +19,66 @@ type PricingPlan struct {
 // PricingPlans is a slice of pricing plans
 type PricingPlans []PricingPlan
 
+// Sort sorts the pricing plans in ascending order by their ValidFrom date.
+// A plan without ValidFrom date is considered to be valid since ever and is thus sorted first.
+func (p PricingPlans) Sort() {
+	sort.SliceStable(p, func(i, j int) bool {
+		if p[j].ValidFrom == nil {
+			return false
+		}
+		return p[i].ValidFrom == nil || p[i].ValidFrom.Before(*p[j].ValidFrom)
+	})
+}
+
+func (p PricingPlans) validAt(date time.Time) *PricingPlan {
+	for index := range p {
+		plan := &p[index]
+		if (plan.ValidFrom == nil || !date.Before(*plan.ValidFrom)) && (plan.ValidTo == nil || date.Before(*plan.ValidTo)) {
+			return plan
+		}
+	}
+	return nil
+}
+
 // Series combines pricing plans and meter readings. It offers methods to calculate the
 // costs and consumption in a certain time interval.
 type Series struct {
-	Name              string        // the name of the series
-	ConsumptionFormat string        // the format used for the consumption, e.g. %.2f kWh
-	CurrencyFormat    string        // the format used for the currency, e.g. %.2f Euro,
-	PricingPlans      PricingPlans  // the collection of pricing plans
-	MeterReadings     MeterReadings // the collection of meter readings.
+	Name              string            // the name of the series
+	ConsumptionFormat string            // the format used for the consumption, e.g. %.2f kWh
+	CurrencyFormat    string            // the format used for the currency, e.g. %.2f Euro,
+	PricingPlans      PricingPlans      // the collection of pricing plans
+	MeterReadings     MeterReadings     // the collection of meter readings.
+	AdvancePayments   AdvancePayments   // the installments paid in advance, one per billing period
+	PriceCaps         PriceCaps         // the price caps reducing the unit price for a quota of the consumption
+	ReliefCredits     ReliefCredits     // one-time relief credits, e.g. state subsidies
+	OneTimeCharges    OneTimeCharges    // fees, bonuses and credits that are not billed per unit or month
+	Rounding          RoundingRule      // how the provider rounds the amounts of a bill
+	Currency          string            // the code of the currency the series is billed in, e.g. EUR; may be empty
+	ConversionFactors ConversionFactors // the factors converting the meter unit into the billing unit, e.g. m³ into kWh
+	MeterFormat       string            // the format of the consumption in the meter unit, only used with conversion factors
+	Unit              Unit              // the unit consumptions are billed in, e.g. kWh; may be unknown
+	MeterUnit         Unit              // the unit the meter counts in, e.g. m³; may be unknown if it equals the Unit
+	EmissionFactors   EmissionFactors   // the CO₂ emissions and primary energy per unit of consumption
+	Interpolation     Interpolation     // how the consumption is distributed between meter readings; linear if nil
+	Heating           bool              // whether the consumption depends on the weather, e.g. gas for heating
+	DegreeDays        *DegreeDays       // the heating degree days used to normalize the consumption of heating series
+	Budgets           Budgets           // the monthly budgets the costs are compared with
+	Goals             Goals             // the goals to reduce the consumption
+	ReadingInterval   *ReadingInterval  // how often the meter is read; nil if the readings are irregular
+	Contracts         Contracts         // the supply contracts of the series
+	Meters            Meters            // the physical meters the readings were taken on
+	IgnoreEstimates   bool              // whether estimated readings are skipped if other readings surround them
+	Events            Events            // annotated periods like vacations, optionally excluded from averages
+	Households        Households        // the number of occupants and the living area over time
 }
 
 // CostsAndConsumption computes the costs and consumption of a certain series.
-// Between to meter readings, the consumption is calculated as if it would change linearly.
+// Between to meter readings, the consumption is distributed according to the interpolation of the series.
+// The costs include the one-time charges between start and end (see OneTimeCharges.Between); the relief
+// granted by price caps and relief credits is already subtracted from the costs (see Relief).
+//
+// The costs are rounded according to the rounding rule of the series as if the time span was billed
+// as a whole, e.g. with RoundMonthly the costs are the sum of the rounded costs of every month.
 //
 // All dates are treated with time 0:00. Thus, the start day is always inclusive and the end day is exclusive.
 //
@@ -43,33 +92,75 @@ type Series struct {
 // * the last pricing plan's validTo must eiether be after end or be nil
 // * plans do have to start at the first of the month
 // * the series must have both pricing plans and meter readings initialized
-func (s *Series) CostsAndConsumption(start time.Time, end time.Time) (float64, float64) {
-	costs := 0.0
+func (s *Series) CostsAndConsumption(start time.Time, end time.Time) (Money, float64) {
+	if s.Rounding.Stage != RoundMonthly {
+		costs, consumption := s.itemizedCostsAndConsumption(start, end)
+		return s.Rounding.round(RoundFinal, costs), consumption
+	}
+	costs := Money(0)
+	totalConsumption := 0.0
+	for monthStart := start; monthStart.Before(end); {
+		monthEnd := firstOfNextMonth(monthStart)
+		if end.Before(monthEnd) {
+			monthEnd = end
+		}
+		monthCosts, consumption := s.itemizedCostsAndConsumption(monthStart, monthEnd)
+		costs = costs + s.Rounding.round(RoundMonthly, monthCosts)
+		totalConsumption = totalConsumption + consumption
+		monthStart = monthEnd
+	}
+	return costs, totalConsumption
+}
+
+// itemizedCostsAndConsumption computes the costs as sum of line items, which are rounded
+// if the rounding rule of the series demands it. The sum itself is not rounded.
+func (s *Series) itemizedCostsAndConsumption(start time.Time, end time.Time) (Money, float64) {
+	costs := Money(0)
+	totalConsumption := 0.0
+	for _, segment := range s.planSegments(start, end) {
+		consumption := s.Consumption(segment.start, segment.end)
+		totalConsumption = totalConsumption + consumption
+		unitCosts := s.Rounding.round(RoundLineItem, segment.plan.UnitPrice.Multiply(consumption))
+		baseCosts := s.Rounding.round(RoundLineItem, segment.plan.BasePrice*Money(monthsBetween(segment.start, segment.end)))
+		costs = costs + unitCosts + baseCosts
+	}
+	return costs + s.oneTimeCharges(start, end) - s.Relief(start, end), totalConsumption
+}
+
+// planSegment is a part of a time span in which a single pricing plan is valid.
+type planSegment struct {
+	plan  PricingPlan
+	start time.Time
+	end   time.Time
+}
+
+// planSegments splits the time between start and end into segments with one pricing plan each.
+// See CostsAndConsumption for the assumptions about the pricing plans.
+func (s *Series) planSegments(start time.Time, end time.Time) []planSegment {
+	result := make([]planSegment, 0, 1)
 	index := 0
 	plans := make(PricingPlans, len(s.PricingPlans))
 	copy(plans, s.PricingPlans)
 	if len(plans) > 0 && plans[0].ValidFrom == nil {
-		plans[0].ValidFrom = &start
+		validFrom := start
+		plans[0].ValidFrom = &validFrom
 	}
 	for index < len(plans) && plans[index].ValidTo != nil && (plans[index].ValidTo.Before(start) || plans[index].ValidTo.Equal(start)) {
 		index = index + 1
 	}
-	totalConsumption := 0.0
 	for index < len(plans) && plans[index].ValidFrom.Before(end) {
 		plan := plans[index]
 		tmpEnd := end
 		if plan.ValidTo != nil && plan.ValidTo.Before(end) {
 			tmpEnd = *plan.ValidTo
 		}
-		consumption := s.MeterReadings.Consumption(start, tmpEnd)
-		totalConsumption = totalConsumption + consumption
-		costs = costs + consumption*plan.UnitPrice + plan.BasePrice*float64(monthsBetween(start, tmpEnd))
+		result = append(result, planSegment{plan: plan, start: start, end: tmpEnd})
 		if index < len(plans)-1 {
 			start = *plans[index+1].ValidFrom
 		}
 		index = index + 1
 	}
-	return costs, totalConsumption
+	return result
 }
 
 // Returns the number of different months between the given times,
@@ -88,10 +179,27 @@ func monthsBetween(start time.Time, end time.Time) int {
 type Statistics struct {
 	ValidFrom         time.Time
 	ValidTo           time.Time
-	Costs             float64
-	Consumption       float64
+	Costs             Money
+	Consumption       float64 // the consumption in the billing unit
+	RawConsumption    float64 // the consumption in the meter unit, differs from Consumption only with conversion factors
+	Normalized        float64 // the consumption normalized to normal weather, zero without degree days (see DegreeDays.Normalize)
+	Relief            Money   // the relief granted by price caps and relief credits, already subtracted from the costs
+	OneTimeCharges    Money   // the one-time charges (negative for credits), already included in the costs
+	Payments          Money   // the advance payments made in the time interval
+	Balance           Money   // the sum of advance payments minus costs since the start of the billing period
+	Budget            Money   // the budget of the time interval (see Budgets.Between)
+	Emissions         float64 // the emissions caused by the consumption in kg CO₂ (see Series.Footprint)
+	PrimaryEnergy     float64 // the primary energy needed for the consumption
+	Events            Events  // the events overlapping the time interval
+	Occupants         float64 // the average number of occupants in the time interval, 0 if unknown (see Households.Between)
+	Area              float64 // the average living area in m² in the time interval, 0 if unknown
 	ConsumptionFormat string
+	MeterFormat       string
+	Unit              Unit // the unit of the consumption, may be unknown
+	MeterUnit         Unit // the unit of the raw consumption, may be unknown
 	CurrencyFormat    string
+	Currency          string       // the code of the currency of all amounts, may be empty (see Convert)
+	Rounding          RoundingRule // the rounding rule used for the costs and their totals
 }
 
 // FormatConsumption formats the consumption of the statistics
@@ -104,6 +212,15 @@ func (s *Statistics) FormatConsumption() string {
 	return fmt.Sprintf(s.ConsumptionFormat, s.Consumption)
 }
 
+// FormatRawConsumption formats the consumption in the meter unit according to
+// the Statistics's MeterFormat field. Uses a reasonable default format if the MeterFormat is empty.
+func (s *Statistics) FormatRawConsumption() string {
+	if len(s.MeterFormat) == 0 {
+		return fmt.Sprintf("%.2f", s.RawConsumption)
+	}
+	return fmt.Sprintf(s.MeterFormat, s.RawConsumption)
+}
+
 // CurrencyFormat formats the costs of the statistics
 // according to the Statistic's CostsFormat field.
 // Uses a reasonable default format if the CurrencyFormat is empty.
@@ -117,33 +234,70 @@ func (s *Statistics) FormatCosts() string {
 // Monthly statistics computes costs and consumption for every month in the specified time span.
 // The result is returned as Monthly Statistics, which can be rendered as table.
 // The monthly statistics are sorted ascendingly with earliest months first.
+//
+// The costs of a month are only rounded if the rounding rule of the series rounds line items or months;
+// with RoundFinal, only the totals of the statistics are rounded (see MonthlyStatistics.Total).
 func (s *Series) MonthlyStatistics(start time.Time, end time.Time) MonthlyStatistics {
-	nextTime := func(date time.Time) time.Time {
-		addedStart := date.AddDate(0, 1, 0)
-		month := addedStart.Month()
-		year := addedStart.Year()
-		monthEnd := CreateDate(year, int(month), 1)
-		return monthEnd
-	}
-	return s.granularCosts(start, end, nextTime)
+	return s.granularCosts(start, end, firstOfNextMonth)
 }
 
-func (s *Series) granularCosts(start time.Time, end time.Time, nextTime func(date time.Time) time.Time) []Statistics {
+// firstOfNextMonth returns the first day of the month after the date's month.
+func firstOfNextMonth(date time.Time) time.Time {
+	addedStart := date.AddDate(0, 1, 0)
+	month := addedStart.Month()
+	year := addedStart.Year()
+	monthEnd := CreateDate(year, int(month), 1)
+	return monthEnd
+}
+
+func (s *Series) granularCosts(start time.Time, end time.Time, nextTime func(date time.Time) time.Time) MonthlyStatistics {
 	result := make([]Statistics, 0, 0)
 	monthStart := start
+	balance := Money(0)
+	var billingPeriod *AdvancePayment
 	for monthStart.Before(end) {
 		monthEnd := nextTime(monthStart)
 		if end.Before(monthEnd) {
 			monthEnd = end
 		}
-		costs, cons := s.CostsAndConsumption(monthStart, monthEnd)
+		costs, cons := s.itemizedCostsAndConsumption(monthStart, monthEnd)
+		costs = s.Rounding.round(RoundMonthly, costs)
+		emissions, primaryEnergy := s.Footprint(monthStart, monthEnd)
 		stats := Statistics{
 			ValidFrom:         monthStart,
 			ValidTo:           monthEnd,
 			Costs:             costs,
 			Consumption:       cons,
+			RawConsumption:    s.meterConsumption(monthStart, monthEnd),
+			Emissions:         emissions,
+			PrimaryEnergy:     primaryEnergy,
+			Relief:            s.Relief(monthStart, monthEnd),
+			OneTimeCharges:    s.oneTimeCharges(monthStart, monthEnd),
+			Budget:            s.Budgets.Between(monthStart, monthEnd),
+			Events:            s.Events.Between(monthStart, monthEnd),
 			ConsumptionFormat: s.ConsumptionFormat,
+			MeterFormat:       s.MeterFormat,
+			Unit:              s.Unit,
+			MeterUnit:         s.MeterUnit,
 			CurrencyFormat:    s.CurrencyFormat,
+			Currency:          s.Currency,
+			Rounding:          s.Rounding,
+		}
+		stats.Occupants, stats.Area = s.Households.Between(monthStart, monthEnd)
+		if s.DegreeDays != nil {
+			stats.Normalized = s.DegreeDays.Normalize(cons, monthStart, monthEnd)
+		}
+		if payment := s.AdvancePayments.validAt(monthStart); payment != nil {
+			if payment != billingPeriod {
+				billingPeriod = payment
+				balance = 0
+				if before := s.granularCosts(payment.ValidFrom, monthStart, nextTime); len(before) > 0 {
+					balance = before[len(before)-1].Balance
+				}
+			}
+			stats.Payments = payment.Payments(monthStart, monthEnd)
+			balance = balance + stats.Payments - costs
+			stats.Balance = balance
 		}
 		result = append(result, stats)
 		monthStart = monthEnd
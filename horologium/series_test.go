@@ -11,26 +11,26 @@ func testData() *Series {
 	plan0 := PricingPlan{
 		ValidFrom: formatDatePtr(2018, 1, 1),
 		ValidTo:   formatDatePtr(2019, 1, 1),
-		BasePrice: 100,
-		UnitPrice: 100,
+		BasePrice: NewMoney(100),
+		UnitPrice: NewMoney(100),
 	}
 	plan1 := PricingPlan{
 		ValidFrom: formatDatePtr(2019, 1, 1),
 		ValidTo:   formatDatePtr(2019, 8, 1),
-		BasePrice: 10.8,
-		UnitPrice: 2.3,
+		BasePrice: NewMoney(10.8),
+		UnitPrice: NewMoney(2.3),
 	}
 	plan2 := PricingPlan{
 		ValidFrom: formatDatePtr(2019, 8, 1),
 		ValidTo:   formatDatePtr(2019, 10, 1),
-		BasePrice: 11.2,
-		UnitPrice: 2.7,
+		BasePrice: NewMoney(11.2),
+		UnitPrice: NewMoney(2.7),
 	}
 	plan3 := PricingPlan{
 		ValidFrom: formatDatePtr(2019, 10, 1),
 		ValidTo:   formatDatePtr(2019, 12, 31),
-		BasePrice: 11.9,
-		UnitPrice: 3.4,
+		BasePrice: NewMoney(11.9),
+		UnitPrice: NewMoney(3.4),
 	}
 	zeroReading := MeterReading{
 		Count: 85,
@@ -73,20 +73,20 @@ func TestCalculate_Simple(t *testing.T) {
 		name            string
 		start           time.Time
 		end             time.Time
-		wantCosts       float64
+		wantCosts       Money
 		wantConsumption float64
 	}{
 		{
 			name:            "two months (simple)",
 			start:           CreateDate(2019, 4, 15),
 			end:             CreateDate(2019, 5, 31),
-			wantCosts:       379.9548387096775,
+			wantCosts:       NewMoney(379.954839),
 			wantConsumption: 155.80645161290326,
 		}, {
 			name:            "year",
 			start:           CreateDate(2019, 1, 1),
 			end:             CreateDate(2019, 12, 31),
-			wantCosts:       2475.380198019802,
+			wantCosts:       NewMoney(2475.380198),
 			wantConsumption: 847,
 		},
 	}
@@ -104,8 +104,8 @@ func TestCalculate_Simple(t *testing.T) {
 
 func ExampleSeries_CostsAndConsumption() {
 	pricingPlanEnd := CreateDate(2019, 6, 1)
-	p1 := PricingPlan{ValidFrom: nil, ValidTo: &pricingPlanEnd, BasePrice: 10, UnitPrice: .20}
-	p2 := PricingPlan{ValidFrom: &pricingPlanEnd, ValidTo: nil, BasePrice: 10, UnitPrice: .30}
+	p1 := PricingPlan{ValidFrom: nil, ValidTo: &pricingPlanEnd, BasePrice: NewMoney(10), UnitPrice: NewMoney(.20)}
+	p2 := PricingPlan{ValidFrom: &pricingPlanEnd, ValidTo: nil, BasePrice: NewMoney(10), UnitPrice: NewMoney(.30)}
 	plans := PricingPlans{p1, p2}
 	// Simple calculation, we consume constantly 100 units per day:
 	m1 := MeterReading{Date: CreateDate(2019, 5, 1), Count: 1000}
@@ -152,28 +152,28 @@ func TestMonthlyCosts(t *testing.T) {
 	wantDecember := Statistics{
 		ValidFrom:   CreateDate(2018, 12, 1),
 		ValidTo:     CreateDate(2019, 1, 1),
-		Costs:       100,
+		Costs:       NewMoney(100),
 		Consumption: 0,
 	}
 	assertStats(t, wantDecember, got[0], "December")
 	wantJanuary := Statistics{
 		ValidFrom:   CreateDate(2019, 1, 1),
 		ValidTo:     CreateDate(2019, 2, 1),
-		Costs:       39.03762376237624,
+		Costs:       NewMoney(39.03762376237624),
 		Consumption: 12.277227722772281,
 	}
 	assertStats(t, wantJanuary, got[1], "January")
 	wantFebruary := Statistics{
 		ValidFrom:   CreateDate(2019, 2, 1),
 		ValidTo:     CreateDate(2019, 3, 1),
-		Costs:       36.304950495049496,
+		Costs:       NewMoney(36.304950495049496),
 		Consumption: 11.089108910891085,
 	}
 	assertStats(t, wantFebruary, got[2], "February")
 	wantMarch := Statistics{
 		ValidFrom:   CreateDate(2019, 3, 1),
 		ValidTo:     CreateDate(2019, 3, 24),
-		Costs:       31.750495049504952,
+		Costs:       NewMoney(31.750495049504952),
 		Consumption: 9.10891089108911,
 	}
 	assertStats(t, wantMarch, got[3], "March")
@@ -181,8 +181,8 @@ func TestMonthlyCosts(t *testing.T) {
 
 func ExampleSeries_MonthlyStatistics() {
 	pricingPlanEnd := CreateDate(2019, 6, 1)
-	p1 := PricingPlan{ValidFrom: nil, ValidTo: &pricingPlanEnd, BasePrice: 10, UnitPrice: .20}
-	p2 := PricingPlan{ValidFrom: &pricingPlanEnd, ValidTo: nil, BasePrice: 10, UnitPrice: .30}
+	p1 := PricingPlan{ValidFrom: nil, ValidTo: &pricingPlanEnd, BasePrice: NewMoney(10), UnitPrice: NewMoney(.20)}
+	p2 := PricingPlan{ValidFrom: &pricingPlanEnd, ValidTo: nil, BasePrice: NewMoney(10), UnitPrice: NewMoney(.30)}
 	plans := PricingPlans{p1, p2}
 	// Simple calculation, we consume constantly 100 units per day:
 	m1 := MeterReading{Date: CreateDate(2019, 5, 1), Count: 1000}
@@ -217,7 +217,7 @@ func ExampleStatistics_FormatConsumption() {
 }
 
 func ExampleStatistics_FormatCosts() {
-	statistics := Statistics{Costs: 27.856}
+	statistics := Statistics{Costs: NewMoney(27.856)}
 	fmt.Printf("Default format: %s\n", statistics.FormatCosts())
 	statistics.CurrencyFormat = "%.3f €"
 	fmt.Printf("Custom format: %s", statistics.FormatCosts())
@@ -0,0 +1,99 @@
+package horologium
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// Tariff is a set of pricing plans offered by a provider, e.g. loaded
+// from a file containing only a name and plans (see LoadTariffFromReader).
+// Besides the plans, a tariff may come with its own fees, bonuses, and relief.
+type Tariff struct {
+	Name           string         // the name of the tariff
+	PricingPlans   PricingPlans   // the pricing plans of the tariff
+	OneTimeCharges OneTimeCharges // the fees and bonuses of the tariff, e.g. a new-customer bonus
+	PriceCaps      PriceCaps      // the price caps granted with the tariff
+	ReliefCredits  ReliefCredits  // the relief credits granted with the tariff
+}
+
+// TariffComparison contains the costs a tariff would have caused for a certain consumption.
+type TariffComparison struct {
+	Tariff      Tariff
+	Costs       Money   // the costs of the consumption under the tariff
+	BaseCosts   Money   // the part of the costs that does not depend on the consumption
+	Consumption float64 // the consumption the costs are based on
+}
+
+// TariffComparisons is a slice of tariff comparisons.
+type TariffComparisons []TariffComparison
+
+// CompareTariffs replays the meter readings of the series against every tariff and computes
+// the costs the consumption between start and end would have caused under the respective tariff.
+// The replay uses the pricing plans, one-time charges, price caps, and relief credits of the tariff instead of
+// those of the series, so the fees and bonuses of the current contract do not change the costs of other tariffs.
+// Everything else is used as it is, e.g. the units, conversion factors, and rounding of the series.
+// The result is ranked, i.e. sorted ascendingly by costs with the cheapest tariff first.
+func (s *Series) CompareTariffs(tariffs []Tariff, start time.Time, end time.Time) TariffComparisons {
+	noConsumption := MeterReadings{{Date: start}, {Date: end}}
+	result := make(TariffComparisons, 0, len(tariffs))
+	for _, tariff := range tariffs {
+		replay := *s
+		replay.PricingPlans = tariff.PricingPlans
+		replay.OneTimeCharges = tariff.OneTimeCharges
+		replay.PriceCaps = tariff.PriceCaps
+		replay.ReliefCredits = tariff.ReliefCredits
+		costs, consumption := replay.CostsAndConsumption(start, end)
+		replay.MeterReadings = noConsumption
+		baseCosts, _ := replay.CostsAndConsumption(start, end)
+		result = append(result, TariffComparison{Tariff: tariff, Costs: costs, BaseCosts: baseCosts, Consumption: consumption})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Costs < result[j].Costs
+	})
+	return result
+}
+
+// BreakEven returns the consumption for which both tariff comparisons result in the same costs,
+// assuming that the consumption would be distributed in time like the actual consumption.
+// If the costs of both tariffs are never the same for a positive consumption, false is returned.
+func (t *TariffComparison) BreakEven(other *TariffComparison) (float64, bool) {
+	if t.Consumption == 0 || other.Consumption == 0 {
+		return 0, false
+	}
+	unitCosts := (t.Costs - t.BaseCosts).Float() / t.Consumption
+	otherUnitCosts := (other.Costs - other.BaseCosts).Float() / other.Consumption
+	if unitCosts == otherUnitCosts {
+		return 0, false
+	}
+	breakEven := (other.BaseCosts - t.BaseCosts).Float() / (unitCosts - otherUnitCosts)
+	if breakEven <= 0 || math.IsInf(breakEven, 0) {
+		return 0, false
+	}
+	return breakEven, true
+}
+
+// RenderTable renders the ranked tariff comparisons as table. Besides the costs, the table contains the additional
+// costs compared to the cheapest tariff and the consumption at which the tariff would be as cheap as the cheapest one.
+func (t TariffComparisons) RenderTable(writer io.Writer, consumptionFormat string, currencyFormat string) {
+	columns := []tableColumn{{header: "RANK"}, {header: "TARIFF", left: true}, {header: "COSTS"}, {header: "DIFFERENCE"}, {header: "BREAK-EVEN"}}
+	rows := make([][]string, 0, len(t))
+	for index, comparison := range t {
+		stats := Statistics{Costs: comparison.Costs, CurrencyFormat: currencyFormat, ConsumptionFormat: consumptionFormat}
+		costs := stats.FormatCosts()
+		stats.Costs = comparison.Costs - t[0].Costs
+		difference := stats.FormatCosts()
+		breakEven := "-"
+		if index > 0 {
+			breakEven = "never"
+			if value, ok := comparison.BreakEven(&t[0]); ok {
+				stats.Consumption = value
+				breakEven = stats.FormatConsumption()
+			}
+		}
+		rows = append(rows, []string{fmt.Sprintf("%d", index+1), comparison.Tariff.Name, costs, difference, breakEven})
+	}
+	renderTable(writer, columns, rows, nil)
+}
@@ -0,0 +1,104 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testTariffs() (*Series, []Tariff) {
+	series := &Series{MeterReadings: MeterReadings{{Date: CreateDate(2020, 1, 1), Count: 0}, {Date: CreateDate(2020, 7, 1), Count: 1200}}}
+	tariffs := []Tariff{
+		{Name: "Cheap base", PricingPlans: PricingPlans{{BasePrice: NewMoney(5), UnitPrice: NewMoney(0.35)}}},
+		{Name: "Cheap units", PricingPlans: PricingPlans{{BasePrice: NewMoney(15), UnitPrice: NewMoney(0.25)}}},
+		{Name: "Expensive", PricingPlans: PricingPlans{{BasePrice: NewMoney(15), UnitPrice: NewMoney(0.35)}}},
+	}
+	return series, tariffs
+}
+
+func TestSeries_CompareTariffs(t *testing.T) {
+	series, tariffs := testTariffs()
+	got := series.CompareTariffs(tariffs, CreateDate(2020, 1, 1), CreateDate(2020, 7, 1))
+	require.Equal(t, 3, len(got), "every tariff should be compared")
+	assert.Equal(t, "Cheap units", got[0].Tariff.Name, "cheapest tariff should be first")
+	assert.Equal(t, NewMoney(390), got[0].Costs, "costs are wrong")
+	assert.Equal(t, NewMoney(90), got[0].BaseCosts, "base costs are wrong")
+	assert.InDelta(t, 1200, got[0].Consumption, 1e-9, "consumption is wrong")
+	assert.Equal(t, "Cheap base", got[1].Tariff.Name, "second tariff is wrong")
+	assert.Equal(t, NewMoney(450), got[1].Costs, "costs are wrong")
+	assert.Equal(t, "Expensive", got[2].Tariff.Name, "most expensive tariff should be last")
+}
+
+func TestSeries_CompareTariffs_Units(t *testing.T) {
+	series, tariffs := testTariffs()
+	series.MeterUnit, series.Unit = units["Wh"], units["kWh"]
+	series.MeterReadings[1].Count = 1200000
+	got := series.CompareTariffs(tariffs, CreateDate(2020, 1, 1), CreateDate(2020, 7, 1))
+	assert.InDelta(t, 1200, got[0].Consumption, 1e-9, "consumption should be converted into the unit of the series")
+	assert.Equal(t, NewMoney(390), got[0].Costs, "costs are wrong")
+}
+
+func TestSeries_CompareTariffs_IgnoreEstimates(t *testing.T) {
+	series, tariffs := testTariffs()
+	series.MeterReadings = MeterReadings{series.MeterReadings[0], {Date: CreateDate(2020, 4, 1), Count: 1000, Source: ReadingEstimated}, series.MeterReadings[1]}
+	series.IgnoreEstimates = true
+	got := series.CompareTariffs(tariffs, CreateDate(2020, 1, 1), CreateDate(2020, 4, 1))
+	// the estimate is skipped, the consumption is interpolated between the actual readings
+	assert.InDelta(t, 1200.0*91/182, got[0].Consumption, 1e-9, "the estimated reading should be ignored")
+	assert.Equal(t, NewMoney(195), got[0].Costs, "costs are wrong")
+}
+
+func TestSeries_CompareTariffs_OneTimeCharges(t *testing.T) {
+	series, tariffs := testTariffs()
+	series.OneTimeCharges = OneTimeCharges{{Name: "New-customer bonus", Amount: NewMoney(-100), Date: CreateDate(2020, 2, 1)}}
+	tariffs = append(tariffs, Tariff{Name: "Current", PricingPlans: tariffs[2].PricingPlans, OneTimeCharges: series.OneTimeCharges})
+	got := series.CompareTariffs(tariffs, CreateDate(2020, 1, 1), CreateDate(2020, 7, 1))
+	require.Equal(t, 4, len(got), "every tariff should be compared")
+	assert.Equal(t, "Current", got[1].Tariff.Name, "the bonus should only make the tariff defining it cheaper")
+	assert.Equal(t, NewMoney(410), got[1].Costs, "costs of the tariff with bonus are wrong")
+	assert.Equal(t, "Expensive", got[3].Tariff.Name, "the bonus of the series must not apply to other tariffs")
+	assert.Equal(t, NewMoney(510), got[3].Costs, "costs without bonus are wrong")
+}
+
+func TestTariffComparison_BreakEven(t *testing.T) {
+	series, tariffs := testTariffs()
+	got := series.CompareTariffs(tariffs, CreateDate(2020, 1, 1), CreateDate(2020, 7, 1))
+	breakEven, ok := got[1].BreakEven(&got[0])
+	require.True(t, ok, "there should be a break-even point")
+	// 30 + 0.35 * x = 90 + 0.25 * x
+	assert.InDelta(t, 600, breakEven, 1e-9, "break-even consumption is wrong")
+	_, ok = got[2].BreakEven(&got[0])
+	assert.False(t, ok, "the expensive tariff is never cheaper")
+	_, ok = got[2].BreakEven(&got[1])
+	assert.False(t, ok, "tariffs with the same unit price have no break-even point")
+}
+
+func ExampleTariffComparisons_RenderTable() {
+	series, tariffs := testTariffs()
+	got := series.CompareTariffs(tariffs, CreateDate(2020, 1, 1), CreateDate(2020, 7, 1))
+	got.RenderTable(os.Stdout, "%.0f kWh", "%.2f €")
+	// Output:
+	// | RANK |   TARIFF    |  COSTS   | DIFFERENCE | BREAK-EVEN |
+	// |------|-------------|----------|------------|------------|
+	// |    1 | Cheap units | 390.00 € |     0.00 € |          - |
+	// |    2 | Cheap base  | 450.00 € |    60.00 € |    600 kWh |
+	// |    3 | Expensive   | 510.00 € |   120.00 € |      never |
+	// |------|-------------|----------|------------|------------|
+}
+
+func TestLoadTariffFromReader(t *testing.T) {
+	file := `name: "Green Power"
+plans:
+  - {name: "Offer 2021", basePrice: 9.9, unitPrice: 0.28}
+oneTimeCharges:
+  - {name: "Bonus", amount: -50, date: 2021-01-01}`
+	got, err := LoadTariffFromReader(strings.NewReader(file))
+	require.NoError(t, err, "loading the tariff failed")
+	assert.Equal(t, "Green Power", got.Name, "name is wrong")
+	require.Equal(t, 1, len(got.PricingPlans), "number of plans is wrong")
+	assert.Nil(t, got.PricingPlans[0].ValidFrom, "validFrom should be nil if omitted")
+	assert.Equal(t, NewMoney(0.28), got.PricingPlans[0].UnitPrice, "unit price is wrong")
+	assert.Equal(t, OneTimeCharges{{Name: "Bonus", Amount: NewMoney(-50), Date: CreateDate(2021, 1, 1)}}, got.OneTimeCharges, "one-time charges are wrong")
+}
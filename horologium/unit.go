@@ -0,0 +1,102 @@
+package horologium
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Dimension is the physical quantity a unit measures. Only units of the same dimension can be converted into each other.
+type Dimension string
+
+const (
+	// Energy is measured e.g. by power meters and heat meters.
+	Energy Dimension = "energy"
+	// Volume is measured e.g. by water and gas meters.
+	Volume Dimension = "volume"
+	// AllocatorUnits are the dimensionless units of heat-cost allocators, which can only be compared within one building.
+	AllocatorUnits Dimension = "allocator units"
+)
+
+// Unit is a physical unit like kWh or m³.
+type Unit struct {
+	Symbol    string    // the symbol of the unit, e.g. kWh
+	Dimension Dimension // the quantity the unit measures
+	Factor    float64   // the value of one unit in the base unit of the dimension (kWh, m³, and allocator units)
+}
+
+// units contains all known units by their symbol.
+var units = map[string]Unit{
+	"Wh":  {Symbol: "Wh", Dimension: Energy, Factor: 0.001},
+	"kWh": {Symbol: "kWh", Dimension: Energy, Factor: 1},
+	"MWh": {Symbol: "MWh", Dimension: Energy, Factor: 1000},
+	"MJ":  {Symbol: "MJ", Dimension: Energy, Factor: 1 / 3.6},
+	"GJ":  {Symbol: "GJ", Dimension: Energy, Factor: 1000 / 3.6},
+	"l":   {Symbol: "l", Dimension: Volume, Factor: 0.001},
+	"hl":  {Symbol: "hl", Dimension: Volume, Factor: 0.1},
+	"m³":  {Symbol: "m³", Dimension: Volume, Factor: 1},
+	"HKV": {Symbol: "HKV", Dimension: AllocatorUnits, Factor: 1},
+}
+
+// unitAliases contains alternative spellings of unit symbols.
+var unitAliases = map[string]string{"m3": "m³", "L": "l", "units": "HKV"}
+
+// ParseUnit returns the unit with the given symbol, e.g. kWh, m³ (or m3), l, or HKV for heat-cost allocator units.
+func ParseUnit(symbol string) (Unit, error) {
+	trimmed := strings.TrimSpace(symbol)
+	if alias, ok := unitAliases[trimmed]; ok {
+		trimmed = alias
+	}
+	unit, ok := units[trimmed]
+	if !ok {
+		symbols := make([]string, 0, len(units))
+		for known := range units {
+			symbols = append(symbols, known)
+		}
+		sort.Strings(symbols)
+		return Unit{}, fmt.Errorf("unknown unit \"%s\", expected one of %s", symbol, strings.Join(symbols, ", "))
+	}
+	return unit, nil
+}
+
+// String returns the symbol of the unit.
+func (u Unit) String() string {
+	return u.Symbol
+}
+
+// Known returns whether the unit is set, i.e. not the zero value.
+func (u Unit) Known() bool {
+	return u.Dimension != ""
+}
+
+// Convert converts a value measured in this unit into the target unit. An error is returned if
+// the units measure different dimensions, e.g. m³ cannot be converted into kWh (see ConversionFactor for that).
+func (u Unit) Convert(value float64, target Unit) (float64, error) {
+	if !u.Known() || !target.Known() {
+		return 0, fmt.Errorf("cannot convert unknown units")
+	}
+	if u.Dimension != target.Dimension {
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", u.Symbol, u.Dimension, target.Symbol, target.Dimension)
+	}
+	return value * u.Factor / target.Factor, nil
+}
+
+// TotalConsumption returns the consumption of all series over the whole time span in the given unit.
+// Since consumptions of different dimensions cannot be added, an error is returned if a series has no unit
+// or a unit that cannot be converted into the given unit, e.g. when adding the m³ of a water series to kWh.
+func (p PortfolioStatistics) TotalConsumption(unit Unit) (float64, error) {
+	result := 0.0
+	for index, stats := range p.Statistics {
+		for _, stat := range stats {
+			if !stat.Unit.Known() {
+				return 0, fmt.Errorf("the unit of %s is unknown", p.Names[index])
+			}
+			consumption, err := stat.Unit.Convert(stat.Consumption, unit)
+			if err != nil {
+				return 0, fmt.Errorf("cannot add the consumption of %s: %v", p.Names[index], err)
+			}
+			result = result + consumption
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseUnit(t *testing.T) {
+	got, err := ParseUnit("m3")
+	require.NoError(t, err, "parsing the alias failed")
+	assert.Equal(t, Unit{Symbol: "m³", Dimension: Volume, Factor: 1}, got, "alias should be resolved")
+	_, err = ParseUnit("kW")
+	assert.EqualError(t, err, "unknown unit \"kW\", expected one of GJ, HKV, MJ, MWh, Wh, hl, kWh, l, m³", "error message wrong")
+}
+
+func TestUnit_Convert(t *testing.T) {
+	megawattHours, _ := ParseUnit("MWh")
+	kilowattHours, _ := ParseUnit("kWh")
+	gigajoules, _ := ParseUnit("GJ")
+	litres, _ := ParseUnit("l")
+	cubicMetres, _ := ParseUnit("m³")
+	tests := []struct {
+		name   string
+		from   Unit
+		to     Unit
+		value  float64
+		want   float64
+		errMsg string
+	}{
+		{name: "MWh to kWh", from: megawattHours, to: kilowattHours, value: 1.5, want: 1500},
+		{name: "GJ to kWh", from: gigajoules, to: kilowattHours, value: 3.6, want: 1000},
+		{name: "l to m³", from: litres, to: cubicMetres, value: 250, want: 0.25},
+		{name: "m³ to kWh", from: cubicMetres, to: kilowattHours, value: 1, errMsg: "cannot convert m³ (volume) to kWh (energy)"},
+		{name: "unknown", from: Unit{}, to: kilowattHours, value: 1, errMsg: "cannot convert unknown units"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.from.Convert(tt.value, tt.to)
+			if tt.errMsg != "" {
+				assert.EqualError(t, err, tt.errMsg, "error message wrong")
+				return
+			}
+			require.NoError(t, err, "conversion failed")
+			assert.InDelta(t, tt.want, got, 1e-9, "converted value is wrong")
+		})
+	}
+}
+
+func testUnitPortfolio() Portfolio {
+	wattHours, _ := ParseUnit("Wh")
+	kilowattHours, _ := ParseUnit("kWh")
+	cubicMetres, _ := ParseUnit("m³")
+	plans := PricingPlans{{UnitPrice: NewMoney(0.3)}}
+	return Portfolio{
+		{Name: "Power", Unit: kilowattHours, MeterUnit: wattHours, PricingPlans: plans,
+			MeterReadings: MeterReadings{{Date: CreateDate(2021, 1, 1), Count: 0}, {Date: CreateDate(2021, 2, 1), Count: 310000}}},
+		{Name: "Heat", Unit: kilowattHours, PricingPlans: plans,
+			MeterReadings: MeterReadings{{Date: CreateDate(2021, 1, 1), Count: 0}, {Date: CreateDate(2021, 2, 1), Count: 1000}}},
+		{Name: "Water", Unit: cubicMetres, PricingPlans: plans,
+			MeterReadings: MeterReadings{{Date: CreateDate(2021, 1, 1), Count: 0}, {Date: CreateDate(2021, 2, 1), Count: 5}}},
+	}
+}
+
+func TestSeries_Consumption_MeterUnit(t *testing.T) {
+	series := testUnitPortfolio()[0]
+	assert.InDelta(t, 310, series.Consumption(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1)), 1e-9, "Wh should be converted to kWh")
+	costs, _ := series.CostsAndConsumption(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1))
+	assert.Equal(t, NewMoney(93), costs, "costs should be based on kWh")
+}
+
+func TestPortfolioStatistics_TotalConsumption(t *testing.T) {
+	kilowattHours, _ := ParseUnit("kWh")
+	megawattHours, _ := ParseUnit("MWh")
+	portfolio := testUnitPortfolio()
+	stats := portfolio[:2].MonthlyStatistics(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1))
+	got, err := stats.TotalConsumption(megawattHours)
+	require.NoError(t, err, "adding energy consumptions failed")
+	assert.InDelta(t, 1.31, got, 1e-9, "total consumption is wrong")
+
+	stats = portfolio.MonthlyStatistics(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1))
+	_, err = stats.TotalConsumption(kilowattHours)
+	assert.EqualError(t, err, "cannot add the consumption of Water: cannot convert m³ (volume) to kWh (energy)", "error message wrong")
+	portfolio[2].Unit = Unit{}
+	stats = portfolio.MonthlyStatistics(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1))
+	_, err = stats.TotalConsumption(kilowattHours)
+	assert.EqualError(t, err, "the unit of Water is unknown", "error message wrong")
+}
+
+func ExampleMonthlyStatistics_RenderTable_units() {
+	series := testUnitPortfolio()[0]
+	series.MonthlyStatistics(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1)).RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR | CONSUMPTION (kWh) | METER (Wh) | COSTS |
+	// |-----------|------|-------------------|------------|-------|
+	// | January   | 2021 |            310.00 |  310000.00 | 93.00 |
+	// |-----------|------|-------------------|------------|-------|
+	// | TOTAL     |      |            310.00 |  310000.00 | 93.00 |
+	// |-----------|------|-------------------|------------|-------|
+}
+
+func TestLoadFromReader_Units(t *testing.T) {
+	got, err := LoadFromReader(strings.NewReader("unit: kWh\nmeterUnit: m3\nconversionFactors:\n  - {calorificValue: 11, validFrom: 2021-01-01}"))
+	require.NoError(t, err, "loading failed")
+	assert.Equal(t, "kWh", got.Unit.Symbol, "unit is wrong")
+	assert.Equal(t, "m³", got.MeterUnit.Symbol, "meter unit is wrong")
+
+	_, err = LoadFromReader(strings.NewReader("unit: kWh\nmeterUnit: m3"))
+	assert.EqualError(t, err, "the meter unit m³ cannot be converted to kWh without conversion factors", "error message wrong")
+	_, err = LoadFromReader(strings.NewReader("unit: kwh"))
+	assert.EqualError(t, err, "could not parse unit: unknown unit \"kwh\", expected one of GJ, HKV, MJ, MWh, Wh, hl, kWh, l, m³", "error message wrong")
+}
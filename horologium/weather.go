@@ -0,0 +1,177 @@
+package horologium
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultHeatingLimit is the daily mean temperature (in °C) below which buildings are usually heated.
+const DefaultHeatingLimit = 15.0
+
+// Temperature is the daily mean outside temperature at a certain date.
+type Temperature struct {
+	Date time.Time
+	Mean float64 // the daily mean temperature in °C
+}
+
+// Temperatures is a slice of daily temperatures.
+type Temperatures []Temperature
+
+// Sort sorts the temperatures in ascending order by their date.
+func (t Temperatures) Sort() {
+	sort.SliceStable(t, func(i, j int) bool {
+		return t[i].Date.Before(t[j].Date)
+	})
+}
+
+// LoadTemperaturesFromReader reads daily mean temperatures in CSV format. Every line contains the date
+// and the mean temperature in °C, e.g. "2023-01-01,4.2". A header line starting with "date" is skipped.
+// The returned temperatures are sorted by date.
+func LoadTemperaturesFromReader(reader io.Reader) (Temperatures, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = 2
+	csvReader.TrimLeadingSpace = true
+	csvReader.Comment = '#'
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not read temperatures: %v", err)
+	}
+	result := make(Temperatures, 0, len(records))
+	for index, record := range records {
+		if index == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+		date, err := time.Parse(DateFormat, strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse temperature %d: could not parse date: %v", index, err)
+		}
+		mean, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse temperature %d: could not parse mean: %v", index, err)
+		}
+		result = append(result, Temperature{Date: date, Mean: mean})
+	}
+	result.Sort()
+	return result, nil
+}
+
+// DegreeDays are the heating degree days of a location: every day with a mean temperature below the heating limit
+// contributes the difference between the limit and its mean temperature. The degree days are a measure of how much
+// a building had to be heated.
+//
+// Days without temperature use the normal of their month, i.e. the average degree days of that calendar month
+// over all years with temperatures. Since the normals are computed from the same temperatures, they only describe
+// normal weather if the temperatures cover several years; with a single year, every month is normal.
+// DegreeDays implement LoadProfile for degree-day-weighted interpolation.
+type DegreeDays struct {
+	HeatingLimit float64
+	days         map[string]float64
+	normals      [12]float64
+}
+
+// NewDegreeDays computes the heating degree days from daily mean temperatures.
+func NewDegreeDays(temperatures Temperatures, heatingLimit float64) *DegreeDays {
+	result := &DegreeDays{HeatingLimit: heatingLimit, days: make(map[string]float64, len(temperatures))}
+	var counts [12]int
+	for _, temperature := range temperatures {
+		degreeDays := 0.0
+		if temperature.Mean < heatingLimit {
+			degreeDays = heatingLimit - temperature.Mean
+		}
+		result.days[temperature.Date.Format(DateFormat)] = degreeDays
+		result.normals[temperature.Date.Month()-1] += degreeDays
+		counts[temperature.Date.Month()-1]++
+	}
+	for month, count := range counts {
+		if count > 0 {
+			result.normals[month] = result.normals[month] / float64(count)
+		}
+	}
+	return result
+}
+
+// At returns the degree days of a single day.
+func (d *DegreeDays) At(date time.Time) float64 {
+	if degreeDays, ok := d.days[date.Format(DateFormat)]; ok {
+		return degreeDays
+	}
+	return d.normals[date.Month()-1]
+}
+
+// Between returns the sum of the degree days of all days between start (inclusive) and end (exclusive).
+func (d *DegreeDays) Between(start time.Time, end time.Time) float64 {
+	result := 0.0
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		result = result + d.At(day)
+	}
+	return result
+}
+
+// Normal returns the degree days to be expected between start and end in a year with normal weather.
+func (d *DegreeDays) Normal(start time.Time, end time.Time) float64 {
+	result := 0.0
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		result = result + d.normals[day.Month()-1]
+	}
+	return result
+}
+
+// DailyWeight implements LoadProfile.
+func (d *DegreeDays) DailyWeight(date time.Time) float64 {
+	return d.At(date)
+}
+
+// minimumDailyDegreeDays is the average number of degree days per day below which a time span is hardly heated.
+const minimumDailyDegreeDays = 1.0
+
+// Normalize scales a consumption between start and end to normal weather: the consumption is multiplied with
+// the ratio of the normal to the actual degree days. If the actual or the normal degree days are less than
+// one per day on average, e.g. in summer, hardly any of the consumption is used for heating and it is returned
+// unchanged; otherwise, a few degree days would scale it arbitrarily.
+func (d *DegreeDays) Normalize(consumption float64, start time.Time, end time.Time) float64 {
+	actual, normal := d.Between(start, end), d.Normal(start, end)
+	minimum := minimumDailyDegreeDays * math.Round(end.Sub(start).Hours()/24)
+	if actual < minimum || normal < minimum {
+		return consumption
+	}
+	return consumption * normal / actual
+}
+
+// DegreeDayInterpolation distributes the consumption between two readings proportionally to the heating
+// degree days (see ProfileInterpolation). The degree days are set with Series.UseDegreeDays; without them,
+// the consumption is distributed linearly.
+type DegreeDayInterpolation struct {
+	DegreeDays *DegreeDays
+}
+
+// Interpolate implements Interpolation.
+func (d DegreeDayInterpolation) Interpolate(previous MeterReading, next MeterReading, date time.Time) float64 {
+	if d.DegreeDays == nil {
+		return LinearInterpolation{}.Interpolate(previous, next, date)
+	}
+	return ProfileInterpolation{Profile: d.DegreeDays}.Interpolate(previous, next, date)
+}
+
+// UseDegreeDays sets the degree days of the location of the series. Heating series (see Series.Heating)
+// use them to normalize their consumption, and the degree-day interpolation uses them to distribute the consumption.
+func (s *Series) UseDegreeDays(degreeDays *DegreeDays) {
+	if s.Heating {
+		s.DegreeDays = degreeDays
+	}
+	if _, ok := s.Interpolation.(DegreeDayInterpolation); ok {
+		s.Interpolation = DegreeDayInterpolation{DegreeDays: degreeDays}
+	}
+}
+
+// UseDegreeDays sets the degree days of all series of the portfolio (see Series.UseDegreeDays).
+func (p Portfolio) UseDegreeDays(degreeDays *DegreeDays) {
+	for _, series := range p {
+		series.UseDegreeDays(degreeDays)
+	}
+}
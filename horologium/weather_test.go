@@ -0,0 +1,100 @@
+package horologium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func constantTemperatures(start time.Time, end time.Time, mean float64) Temperatures {
+	result := Temperatures{}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		result = append(result, Temperature{Date: day, Mean: mean})
+	}
+	return result
+}
+
+func testDegreeDays() *DegreeDays {
+	temperatures := constantTemperatures(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1), 0)
+	temperatures = append(temperatures, constantTemperatures(CreateDate(2021, 2, 1), CreateDate(2021, 3, 1), 5)...)
+	temperatures = append(temperatures, constantTemperatures(CreateDate(2022, 1, 1), CreateDate(2022, 2, 1), 5)...)
+	return NewDegreeDays(temperatures, DefaultHeatingLimit)
+}
+
+func TestLoadTemperaturesFromReader(t *testing.T) {
+	got, err := LoadTemperaturesFromReader(strings.NewReader("date,mean\n# station 1234\n2023-01-02, -1.5\n2023-01-01,4.2\n"))
+	require.NoError(t, err, "loading failed")
+	assert.Equal(t, Temperatures{{Date: CreateDate(2023, 1, 1), Mean: 4.2}, {Date: CreateDate(2023, 1, 2), Mean: -1.5}}, got, "temperatures are wrong")
+	_, err = LoadTemperaturesFromReader(strings.NewReader("2023-01-01,warm"))
+	assert.EqualError(t, err, "could not parse temperature 0: could not parse mean: strconv.ParseFloat: parsing \"warm\": invalid syntax", "error message wrong")
+}
+
+func TestDegreeDays(t *testing.T) {
+	degreeDays := NewDegreeDays(Temperatures{{Date: CreateDate(2021, 1, 1), Mean: 3}, {Date: CreateDate(2021, 1, 2), Mean: 16}}, DefaultHeatingLimit)
+	assert.Equal(t, 12.0, degreeDays.At(CreateDate(2021, 1, 1)), "days below the heating limit should count")
+	assert.Equal(t, 0.0, degreeDays.At(CreateDate(2021, 1, 2)), "days above the heating limit should not count")
+	assert.Equal(t, 6.0, degreeDays.At(CreateDate(2022, 1, 1)), "missing days should use the normal of their month")
+	assert.Equal(t, 0.0, degreeDays.At(CreateDate(2022, 7, 1)), "months without temperatures should have no degree days")
+
+	degreeDays = testDegreeDays()
+	assert.Equal(t, 31*12.5, degreeDays.Normal(CreateDate(2022, 1, 1), CreateDate(2022, 2, 1)), "normal degree days are wrong")
+	assert.InDelta(t, 125, degreeDays.Normalize(100, CreateDate(2022, 1, 1), CreateDate(2022, 2, 1)), 1e-9, "a mild winter should be normalized upwards")
+	assert.Equal(t, 100.0, degreeDays.Normalize(100, CreateDate(2022, 7, 1), CreateDate(2022, 8, 1)), "without degree days, the consumption should not change")
+}
+
+func TestDegreeDays_Normalize_FewDegreeDays(t *testing.T) {
+	// a warm September with two cold days in one year, a cold one in the other year
+	temperatures := constantTemperatures(CreateDate(2021, 9, 1), CreateDate(2021, 10, 1), 5)
+	temperatures = append(temperatures, constantTemperatures(CreateDate(2022, 9, 1), CreateDate(2022, 9, 3), 14)...)
+	temperatures = append(temperatures, constantTemperatures(CreateDate(2022, 9, 3), CreateDate(2022, 10, 1), 18)...)
+	degreeDays := NewDegreeDays(temperatures, DefaultHeatingLimit)
+	assert.Equal(t, 2.0, degreeDays.Between(CreateDate(2022, 9, 1), CreateDate(2022, 10, 1)), "degree days are wrong")
+	assert.Equal(t, 100.0, degreeDays.Normalize(100, CreateDate(2022, 9, 1), CreateDate(2022, 10, 1)), "a month with very few degree days should not be scaled")
+	assert.InDelta(t, 100.0*151/300, degreeDays.Normalize(100, CreateDate(2021, 9, 1), CreateDate(2021, 10, 1)), 1e-9, "a heated month should be scaled")
+}
+
+func TestDegreeDayInterpolation(t *testing.T) {
+	readings := MeterReadings{{Date: CreateDate(2021, 1, 1), Count: 0}, {Date: CreateDate(2021, 3, 1), Count: 1000}}
+	got := readings.InterpolatedConsumption(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1), DegreeDayInterpolation{DegreeDays: testDegreeDays()})
+	assert.InDelta(t, 1000*31*15/(31*15+28*10.0), got, 1e-9, "consumption should follow the degree days")
+	got = readings.InterpolatedConsumption(CreateDate(2021, 1, 1), CreateDate(2021, 2, 1), DegreeDayInterpolation{})
+	assert.InDelta(t, 1000*31/59.0, got, 1e-9, "without degree days, the consumption should be linear")
+}
+
+func TestSeries_UseDegreeDays(t *testing.T) {
+	degreeDays := testDegreeDays()
+	power := &Series{Interpolation: DegreeDayInterpolation{}}
+	gas := &Series{Heating: true}
+	Portfolio{power, gas}.UseDegreeDays(degreeDays)
+	assert.Nil(t, power.DegreeDays, "only heating series should be normalized")
+	assert.Equal(t, DegreeDayInterpolation{DegreeDays: degreeDays}, power.Interpolation, "the interpolation should use the degree days")
+	assert.Equal(t, degreeDays, gas.DegreeDays, "heating series should be normalized")
+	assert.Nil(t, gas.Interpolation, "the interpolation should not change")
+}
+
+func ExampleMonthlyStatistics_RenderTable_normalized() {
+	series := &Series{
+		Heating:       true,
+		PricingPlans:  PricingPlans{{UnitPrice: NewMoney(0.1)}},
+		MeterReadings: MeterReadings{{Date: CreateDate(2022, 1, 1), Count: 0}, {Date: CreateDate(2022, 2, 1), Count: 1000}},
+	}
+	series.UseDegreeDays(testDegreeDays())
+	series.MonthlyStatistics(CreateDate(2022, 1, 1), CreateDate(2022, 2, 1)).RenderTable(os.Stdout)
+	// Output:
+	// |   MONTH   | YEAR | CONSUMPTION | NORMALIZED | COSTS  |
+	// |-----------|------|-------------|------------|--------|
+	// | January   | 2022 |     1000.00 |    1250.00 | 100.00 |
+	// |-----------|------|-------------|------------|--------|
+	// | TOTAL     |      |     1000.00 |    1250.00 | 100.00 |
+	// |-----------|------|-------------|------------|--------|
+}
+
+func TestLoadFromReader_Heating(t *testing.T) {
+	got, err := LoadFromReader(strings.NewReader("heating: true\ninterpolation: {method: degreeDays}"))
+	require.NoError(t, err, "loading failed")
+	assert.True(t, got.Heating, "heating flag is wrong")
+	assert.Equal(t, DegreeDayInterpolation{}, got.Interpolation, "interpolation is wrong")
+}